@@ -0,0 +1,43 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultConcurrency is how many containers batch operations like
+// StopContainers operate on at once.
+const defaultConcurrency = 8
+
+// forEach calls f on each of items, running at most concurrency calls at
+// once. If any calls fail the returned error says which items they failed
+// for, in the order of items.
+func forEach(items []string, concurrency int, f func(item string) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = f(item)
+		}(i, item)
+	}
+	wg.Wait()
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", items[i], err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d failed: %s", len(failures), len(items), strings.Join(failures, "; "))
+	}
+	return nil
+}
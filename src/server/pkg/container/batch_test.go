@@ -0,0 +1,43 @@
+package container
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestForEach(t *testing.T) {
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	release := make(chan struct{})
+	items := []string{"a", "b", "c", "d", "e"}
+	errCh := make(chan error)
+	go func() {
+		errCh <- forEach(items, 2, func(item string) error {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+			<-release
+			lock.Lock()
+			running--
+			lock.Unlock()
+			if item == "b" || item == "d" {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}()
+	for range items {
+		release <- struct{}{}
+	}
+	err := <-errCh
+	require.YesError(t, err)
+	require.Equal(t, "2 of 5 failed: b: failed; d: failed", err.Error())
+	require.True(t, maxRunning <= 2)
+	require.NoError(t, forEach(nil, 2, func(string) error { return errors.New("unreachable") }))
+}
@@ -0,0 +1,63 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// capabilities are the names of the Linux capabilities, without their
+// "CAP_" prefix.
+var capabilities = map[string]bool{
+	"AUDIT_CONTROL":      true,
+	"AUDIT_READ":         true,
+	"AUDIT_WRITE":        true,
+	"BLOCK_SUSPEND":      true,
+	"BPF":                true,
+	"CHECKPOINT_RESTORE": true,
+	"CHOWN":              true,
+	"DAC_OVERRIDE":       true,
+	"DAC_READ_SEARCH":    true,
+	"FOWNER":             true,
+	"FSETID":             true,
+	"IPC_LOCK":           true,
+	"IPC_OWNER":          true,
+	"KILL":               true,
+	"LEASE":              true,
+	"LINUX_IMMUTABLE":    true,
+	"MAC_ADMIN":          true,
+	"MAC_OVERRIDE":       true,
+	"MKNOD":              true,
+	"NET_ADMIN":          true,
+	"NET_BIND_SERVICE":   true,
+	"NET_BROADCAST":      true,
+	"NET_RAW":            true,
+	"PERFMON":            true,
+	"SETFCAP":            true,
+	"SETGID":             true,
+	"SETPCAP":            true,
+	"SETUID":             true,
+	"SYSLOG":             true,
+	"SYS_ADMIN":          true,
+	"SYS_BOOT":           true,
+	"SYS_CHROOT":         true,
+	"SYS_MODULE":         true,
+	"SYS_NICE":           true,
+	"SYS_PACCT":          true,
+	"SYS_PTRACE":         true,
+	"SYS_RAWIO":          true,
+	"SYS_RESOURCE":       true,
+	"SYS_TIME":           true,
+	"SYS_TTY_CONFIG":     true,
+	"WAKE_ALARM":         true,
+}
+
+// parseCapability parses a capability given with or without its "CAP_"
+// prefix, e.g. "NET_ADMIN" or "cap_net_admin", or "ALL" for every
+// capability. It returns the capability in the form docker expects.
+func parseCapability(capability string) (string, error) {
+	name := strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+	if name != "ALL" && !capabilities[name] {
+		return "", fmt.Errorf("unknown capability %q", capability)
+	}
+	return name, nil
+}
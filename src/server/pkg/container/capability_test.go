@@ -0,0 +1,20 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestParseCapability(t *testing.T) {
+	for _, capability := range []string{"NET_ADMIN", "net_admin", "CAP_NET_ADMIN"} {
+		name, err := parseCapability(capability)
+		require.NoError(t, err)
+		require.Equal(t, "NET_ADMIN", name)
+	}
+	name, err := parseCapability("all")
+	require.NoError(t, err)
+	require.Equal(t, "ALL", name)
+	_, err = parseCapability("NET_WIZARD")
+	require.YesError(t, err)
+}
@@ -0,0 +1,97 @@
+package container
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+const (
+	defaultDialTimeout     = 10 * time.Second
+	defaultKeepAlive       = 30 * time.Second
+	defaultMaxIdleConns    = 4
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
+// ClientConfig configures how a Client connects to the docker daemon. Zero
+// fields take their default values.
+type ClientConfig struct {
+	// Host is the address of the daemon, by default it's read from the
+	// environment like NewDockerClientFromEnv.
+	Host string
+	// DialTimeout is how long connecting to the daemon can take before
+	// failing, 10 seconds by default.
+	DialTimeout time.Duration
+	// KeepAlive is the TCP keepalive period of connections to the daemon, 30
+	// seconds by default.
+	KeepAlive time.Duration
+	// MaxIdleConns is how many idle connections to the daemon are kept open
+	// for reuse, 4 by default.
+	MaxIdleConns int
+	// IdleConnTimeout is how long idle connections to the daemon are kept
+	// open, 90 seconds by default.
+	IdleConnTimeout time.Duration
+	// Timeout limits how long each request to the daemon can take, including
+	// reading its response. It applies to streaming requests too, such as
+	// following logs, so it's unlimited by default.
+	Timeout time.Duration
+}
+
+// NewClientWithConfig creates a Client which connects to the daemon as
+// configured by config. The vendored docker client uses its own transport
+// for unix sockets, so for daemons listening on one only DialTimeout,
+// KeepAlive and Timeout apply.
+func NewClientWithConfig(config ClientConfig) (*Client, error) {
+	config = config.withDefaults()
+	host := config.Host
+	if host == "" {
+		host = dockerHost()
+	}
+	client, err := newDockerClient(host)
+	if err != nil {
+		return nil, err
+	}
+	config.configure(client)
+	return &Client{client: client}, nil
+}
+
+// withDefaults returns config with its zero fields set to their defaults.
+func (config ClientConfig) withDefaults() ClientConfig {
+	if config.DialTimeout == 0 {
+		config.DialTimeout = defaultDialTimeout
+	}
+	if config.KeepAlive == 0 {
+		config.KeepAlive = defaultKeepAlive
+	}
+	if config.MaxIdleConns == 0 {
+		config.MaxIdleConns = defaultMaxIdleConns
+	}
+	if config.IdleConnTimeout == 0 {
+		config.IdleConnTimeout = defaultIdleConnTimeout
+	}
+	return config
+}
+
+// configure applies config to client's dialer and transport.
+func (config ClientConfig) configure(client *docker.Client) {
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+	// On Windows the dialer connects to named pipes, which it should keep
+	// doing.
+	if _, ok := client.Dialer.(*net.Dialer); ok {
+		client.Dialer = dialer
+	}
+	// SetTimeout also sets the timeout of the client's unix socket
+	// transport, which HTTPClient isn't used for.
+	client.SetTimeout(config.Timeout)
+	if transport, ok := client.HTTPClient.Transport.(*http.Transport); ok {
+		transport.Dial = dialer.Dial
+		transport.DisableKeepAlives = false
+		transport.MaxIdleConnsPerHost = config.MaxIdleConns
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+}
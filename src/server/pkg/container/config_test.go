@@ -0,0 +1,59 @@
+package container
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestClientConfigConfigure(t *testing.T) {
+	client, err := docker.NewClient("tcp://localhost:2375")
+	require.NoError(t, err)
+	ClientConfig{MaxIdleConns: 2, Timeout: time.Minute}.withDefaults().configure(client)
+	require.Equal(t, time.Minute, client.HTTPClient.Timeout)
+	dialer, ok := client.Dialer.(*net.Dialer)
+	require.True(t, ok)
+	require.Equal(t, defaultDialTimeout, dialer.Timeout)
+	require.Equal(t, defaultKeepAlive, dialer.KeepAlive)
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.False(t, transport.DisableKeepAlives)
+	require.Equal(t, 2, transport.MaxIdleConnsPerHost)
+	require.Equal(t, defaultIdleConnTimeout, transport.IdleConnTimeout)
+}
+
+func TestClientConfigTimeoutUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "container-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+
+	client, err := docker.NewClient("unix://" + socket)
+	require.NoError(t, err)
+	ClientConfig{Timeout: 100 * time.Millisecond}.withDefaults().configure(client)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- client.Ping()
+	}()
+	select {
+	case err := <-errCh:
+		require.YesError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("request over a unix socket ignored the timeout")
+	}
+}
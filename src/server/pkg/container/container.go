@@ -0,0 +1,755 @@
+// Package container contains helpers for starting, inspecting and tearing down
+// docker containers.
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+const (
+	defaultDockerHost = "unix:///var/run/docker.sock"
+	// defaultWindowsDockerHost is the named pipe that the docker daemon
+	// listens on on Windows.
+	defaultWindowsDockerHost = "npipe:////./pipe/docker_engine"
+	// waitPollInterval is how often functions that wait on a container, like
+	// WaitContainerWithContext, check its state.
+	waitPollInterval = 100 * time.Millisecond
+	// defaultStopTimeout is the number of seconds StopContainer gives a
+	// container to exit before killing it.
+	defaultStopTimeout = 5
+)
+
+var (
+	// DefaultConfig is the docker.Config returned by DefaultContainerConfig.
+	//
+	// Deprecated: use DefaultContainerConfig, which returns a new copy each
+	// time it's called. Containers are no longer started with DefaultConfig,
+	// so changes to it don't affect them.
+	DefaultConfig = DefaultContainerConfig()
+
+	// ErrContainerPaused is returned when pausing a container that's already
+	// paused.
+	ErrContainerPaused = errors.New("container is already paused")
+	// ErrContainerNotPaused is returned when unpausing a container that isn't
+	// paused.
+	ErrContainerNotPaused = errors.New("container is not paused")
+)
+
+// DefaultContainerConfig returns the docker.Config that containers are started
+// with if no other config is given. Each call returns a new copy, so callers
+// are free to modify it.
+func DefaultContainerConfig() docker.Config {
+	return docker.Config{
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		OpenStdin:    true,
+		StdinOnce:    true,
+	}
+}
+
+// Client wraps a single docker client so that all operations made through it
+// share one connection to the docker daemon.
+type Client struct {
+	client DockerClient
+}
+
+// NewClient creates a Client configured from the environment, see
+// NewDockerClientFromEnv. It uses the default ClientConfig.
+func NewClient() (*Client, error) {
+	return NewClientWithConfig(ClientConfig{})
+}
+
+// NewClientFromDockerClient creates a Client which makes its requests using
+// client, which is usually a *docker.Client.
+func NewClientFromDockerClient(client DockerClient) *Client {
+	return &Client{client: client}
+}
+
+// NewClientWithHost is like NewClient but connects to the daemon at host,
+// e.g. "unix:///var/run/docker.sock" or "tcp://localhost:2376", regardless of
+// DOCKER_HOST.
+func NewClientWithHost(host string) (*Client, error) {
+	return NewClientWithConfig(ClientConfig{Host: host})
+}
+
+// NewDockerClientFromEnv returns a docker client configured from the
+// DOCKER_HOST, DOCKER_TLS_VERIFY, DOCKER_CERT_PATH and DOCKER_API_VERSION
+// environment variables, and DOCKER_CERT_FILE, DOCKER_KEY_FILE and
+// DOCKER_CA_FILE, which override the names of the TLS files in
+// DOCKER_CERT_PATH. If DOCKER_API_VERSION isn't set the client uses the
+// daemon's latest API version. If DOCKER_HOST isn't set the client connects
+// to the daemon's named pipe on Windows. Elsewhere it connects to the
+// rootless daemon's socket in XDG_RUNTIME_DIR if there is one, and otherwise
+// to /var/run/docker.sock.
+func NewDockerClientFromEnv() (*docker.Client, error) {
+	return newDockerClient(dockerHost())
+}
+
+// dockerHost returns the address of the daemon that clients created from the
+// environment connect to.
+func dockerHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	if runtime.GOOS == "windows" {
+		return defaultWindowsDockerHost
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		socket := filepath.Join(runtimeDir, "docker.sock")
+		if _, err := os.Stat(socket); err == nil {
+			return "unix://" + socket
+		}
+	}
+	return defaultDockerHost
+}
+
+// newDockerClient returns a docker client for the daemon at host, configured
+// from the environment like NewDockerClientFromEnv.
+func newDockerClient(host string) (*docker.Client, error) {
+	apiVersion := os.Getenv("DOCKER_API_VERSION")
+	tlsVerify := os.Getenv("DOCKER_TLS_VERIFY") != ""
+	certPath := os.Getenv("DOCKER_CERT_PATH")
+	if tlsVerify || certPath != "" {
+		if certPath == "" {
+			return nil, fmt.Errorf("DOCKER_CERT_PATH must be set if DOCKER_TLS_VERIFY is set")
+		}
+		cert, err := tlsFile(certPath, "DOCKER_CERT_FILE", "cert.pem")
+		if err != nil {
+			return nil, err
+		}
+		key, err := tlsFile(certPath, "DOCKER_KEY_FILE", "key.pem")
+		if err != nil {
+			return nil, err
+		}
+		ca, err := tlsFile(certPath, "DOCKER_CA_FILE", "ca.pem")
+		if err != nil {
+			return nil, err
+		}
+		if apiVersion != "" {
+			return docker.NewVersionedTLSClient(host, cert, key, ca, apiVersion)
+		}
+		return docker.NewTLSClient(host, cert, key, ca)
+	}
+	if apiVersion != "" {
+		return docker.NewVersionedClient(host, apiVersion)
+	}
+	return docker.NewClient(host)
+}
+
+// tlsFile returns the path of one of the files used to connect to the daemon
+// over TLS. Its name is read from the environment variable key, or is name if
+// that isn't set, and relative names are relative to certPath.
+func tlsFile(certPath, key, name string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		name = value
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(certPath, name)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("can't read TLS file %s (set %s to override its name): %v", path, key, err)
+	}
+	return path, nil
+}
+
+// RawStartContainer creates and starts a container using opts and returns its
+// id.
+func (c *Client) RawStartContainer(opts docker.CreateContainerOptions) (string, error) {
+	container, err := c.client.CreateContainer(opts)
+	if err != nil {
+		if err == docker.ErrContainerAlreadyExists {
+			return "", &NameInUseError{Name: opts.Name}
+		}
+		if opts.Config != nil {
+			return "", imageError(opts.Config.Image, err)
+		}
+		return "", err
+	}
+	if err := c.client.StartContainer(container.ID, opts.HostConfig); err != nil {
+		return "", err
+	}
+	return container.ID, nil
+}
+
+// StartContainer starts a container running command from image and returns
+// its id.
+func (c *Client) StartContainer(image string, command []string) (string, error) {
+	return c.StartContainerWithEnv(image, command, nil)
+}
+
+// StartNamedContainer is like StartContainer but names the container name. If
+// name is already in use a *NameInUseError is returned.
+func (c *Client) StartNamedContainer(name, image string, command []string) (string, error) {
+	return c.StartContainerWithOptions(StartOptions{
+		Name:    name,
+		Image:   image,
+		Command: command,
+	})
+}
+
+// StartContainerWithEnv is like StartContainer but also sets the environment
+// variables in env in the container.
+func (c *Client) StartContainerWithEnv(image string, command []string, env map[string]string) (string, error) {
+	return c.StartContainerWithOptions(StartOptions{
+		Image:   image,
+		Command: command,
+		Env:     env,
+	})
+}
+
+// StartContainerEntrypoint is like StartContainer but also overrides the
+// image's entrypoint, an empty entrypoint clears it so that command is run
+// directly.
+func (c *Client) StartContainerEntrypoint(image string, entrypoint, command []string) (string, error) {
+	if entrypoint == nil {
+		entrypoint = []string{}
+	}
+	return c.StartContainerWithOptions(StartOptions{
+		Image:      image,
+		Entrypoint: entrypoint,
+		Command:    command,
+	})
+}
+
+// envList converts env to the "KEY=VALUE" form docker expects, sorted by key
+// so that the result is deterministic.
+func envList(env map[string]string) []string {
+	var result []string
+	for _, key := range sortedKeys(env) {
+		result = append(result, fmt.Sprintf("%s=%s", key, env[key]))
+	}
+	return result
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// StopContainer stops the container, killing it if it hasn't exited after 5
+// seconds.
+func (c *Client) StopContainer(id string) error {
+	return c.StopContainerTimeout(id, defaultStopTimeout)
+}
+
+// StopContainerTimeout stops the container, killing it if it hasn't exited
+// after timeout seconds. A timeout of 0 kills the container immediately.
+func (c *Client) StopContainerTimeout(id string, timeout uint) error {
+	return containerError(id, c.client.StopContainer(id, timeout))
+}
+
+// StopContainers stops the containers concurrently, killing each one that
+// hasn't exited after timeout seconds. Containers that have already stopped
+// aren't an error.
+func (c *Client) StopContainers(ids []string, timeout uint) error {
+	if err := forEach(ids, defaultConcurrency, func(id string) error {
+		if err := c.StopContainerTimeout(id, timeout); err != nil {
+			if _, ok := err.(*docker.ContainerNotRunning); ok {
+				return nil
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error stopping containers: %v", err)
+	}
+	return nil
+}
+
+// KillContainer kills the container.
+func (c *Client) KillContainer(id string) error {
+	return c.KillContainerSignal(id, "SIGKILL")
+}
+
+// KillContainerSignal sends signal to the container, see ParseSignal for the
+// accepted formats.
+func (c *Client) KillContainerSignal(id string, signal string) error {
+	s, err := ParseSignal(signal)
+	if err != nil {
+		return err
+	}
+	return containerError(id, c.client.KillContainer(docker.KillContainerOptions{ID: id, Signal: s}))
+}
+
+// StopThenKill sends SIGTERM to the container and kills it if it's still
+// running after grace. It returns the container's exit code.
+func (c *Client) StopThenKill(id string, grace time.Duration) (int, error) {
+	if err := c.KillContainerSignal(id, "SIGTERM"); err != nil {
+		if isDockerError(err, "is not running") {
+			return c.GetExitCode(id)
+		}
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	exitCode, err := c.WaitContainerWithContext(ctx, id)
+	if err != context.DeadlineExceeded {
+		return exitCode, err
+	}
+	if err := c.KillContainer(id); err != nil && !isDockerError(err, "is not running") {
+		return 0, err
+	}
+	return c.WaitContainer(id)
+}
+
+// RestartContainer restarts the container, killing it if it hasn't exited
+// after timeout seconds. Unlike stopping and starting the container this
+// preserves its original host config.
+func (c *Client) RestartContainer(id string, timeout uint) error {
+	return containerError(id, c.client.RestartContainer(id, timeout))
+}
+
+// UpdateContainerResources changes the container's resource limits without
+// restarting it.
+func (c *Client) UpdateContainerResources(id string, res docker.UpdateContainerOptions) error {
+	if err := validateUpdate(res); err != nil {
+		return err
+	}
+	if err := c.client.UpdateContainer(id, res); err != nil {
+		if isContainerNotFound(err) {
+			return containerError(id, err)
+		}
+		return fmt.Errorf("error updating the resources of container %s: %v", id, err)
+	}
+	return nil
+}
+
+// validateUpdate returns an error if any of the resource limits in res are
+// negative.
+func validateUpdate(res docker.UpdateContainerOptions) error {
+	for name, value := range map[string]int{
+		"blkio weight":       res.BlkioWeight,
+		"CPU shares":         res.CPUShares,
+		"CPU period":         res.CPUPeriod,
+		"CPU quota":          res.CPUQuota,
+		"memory limit":       res.Memory,
+		"memory reservation": res.MemoryReservation,
+		"kernel memory":      res.KernelMemory,
+	} {
+		if value < 0 {
+			return fmt.Errorf("invalid %s %d", name, value)
+		}
+	}
+	if res.MemorySwap < -1 {
+		return fmt.Errorf("invalid memory swap limit %d", res.MemorySwap)
+	}
+	return nil
+}
+
+// PauseContainer freezes all of the container's processes.
+func (c *Client) PauseContainer(id string) error {
+	if err := c.client.PauseContainer(id); err != nil {
+		if isDockerError(err, "already paused") {
+			return ErrContainerPaused
+		}
+		return containerError(id, err)
+	}
+	return nil
+}
+
+// UnpauseContainer resumes a container paused by PauseContainer.
+func (c *Client) UnpauseContainer(id string) error {
+	if err := c.client.UnpauseContainer(id); err != nil {
+		if isDockerError(err, "not paused") {
+			return ErrContainerNotPaused
+		}
+		return containerError(id, err)
+	}
+	return nil
+}
+
+// RenameContainer gives the container a new name. If another container
+// already has that name a *NameInUseError is returned.
+func (c *Client) RenameContainer(id, newName string) error {
+	if err := c.client.RenameContainer(docker.RenameContainerOptions{
+		ID:   id,
+		Name: newName,
+	}); err != nil {
+		if isDockerError(err, "already in use") {
+			return &NameInUseError{Name: newName}
+		}
+		return containerError(id, err)
+	}
+	return nil
+}
+
+// RemoveContainer removes the container, if force is true the container is
+// removed even if it's running. Removing a container that doesn't exist is not
+// an error.
+func (c *Client) RemoveContainer(id string, force bool) error {
+	return c.removeContainer(docker.RemoveContainerOptions{ID: id, Force: force})
+}
+
+// RemoveContainerAndVolumes is like RemoveContainer but also removes the
+// volumes associated with the container.
+func (c *Client) RemoveContainerAndVolumes(id string, force bool) error {
+	return c.removeContainer(docker.RemoveContainerOptions{ID: id, Force: force, RemoveVolumes: true})
+}
+
+func (c *Client) removeContainer(opts docker.RemoveContainerOptions) error {
+	if err := c.client.RemoveContainer(opts); err != nil {
+		if _, ok := err.(*docker.NoSuchContainer); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// ListOptions specifies which containers ListContainers returns.
+type ListOptions struct {
+	// All includes containers that aren't running.
+	All bool
+	// Labels restricts the list to containers which have all of these labels.
+	Labels map[string]string
+	// Status restricts the list to containers in this state, e.g. "running"
+	// or "exited".
+	Status string
+}
+
+// ListContainers returns the containers matching opts.
+func (c *Client) ListContainers(opts ListOptions) ([]docker.APIContainers, error) {
+	filters := make(map[string][]string)
+	for key, value := range opts.Labels {
+		filters["label"] = append(filters["label"], fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(filters["label"])
+	if opts.Status != "" {
+		filters["status"] = []string{opts.Status}
+	}
+	containers, err := c.client.ListContainers(docker.ListContainersOptions{
+		All:     opts.All,
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if containers == nil {
+		containers = []docker.APIContainers{}
+	}
+	return containers, nil
+}
+
+// ResolveContainerID returns the full ID of the container with the ID, name
+// or unique ID prefix name, tried in that order like the docker daemon does.
+// An error whose Cause is ErrContainerNotFound is returned if there's no such
+// container, and an error is returned if name is a prefix of more than one
+// container's ID.
+func (c *Client) ResolveContainerID(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("can't resolve an empty container name")
+	}
+	container, err := c.client.InspectContainer(name)
+	if err == nil {
+		return container.ID, nil
+	}
+	if isContainerNotFound(err) {
+		return "", containerError(name, err)
+	}
+	// The daemon fails to inspect ambiguous prefixes, listing the containers
+	// gives a more useful error.
+	containers, listErr := c.client.ListContainers(docker.ListContainersOptions{All: true})
+	if listErr != nil {
+		return "", err
+	}
+	return resolveContainerID(containers, name)
+}
+
+// resolveContainerID returns the full ID of the container in containers with
+// the ID, name or unique ID prefix name, tried in that order.
+func resolveContainerID(containers []docker.APIContainers, name string) (string, error) {
+	for _, container := range containers {
+		if container.ID == name {
+			return container.ID, nil
+		}
+	}
+	for _, container := range containers {
+		for _, containerName := range container.Names {
+			// Names are prefixed with "/", linked containers' aliases
+			// are of the form "/linker/alias" and so never match.
+			if containerName == "/"+name {
+				return container.ID, nil
+			}
+		}
+	}
+	var ids []string
+	for _, container := range containers {
+		if strings.HasPrefix(container.ID, name) {
+			ids = append(ids, container.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", containerError(name, &docker.NoSuchContainer{ID: name})
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("%q is ambiguous, it's a prefix of containers %s", name, strings.Join(ids, ", "))
+}
+
+// CommitContainer snapshots the container's filesystem into a new image
+// tagged repo:tag and returns the image's id.
+func (c *Client) CommitContainer(id string, repo, tag string) (string, error) {
+	return c.CommitContainerMessage(id, repo, tag, "", "")
+}
+
+// CommitContainerMessage is like CommitContainer but also records a commit
+// message and author in the image.
+func (c *Client) CommitContainerMessage(id string, repo, tag, message, author string) (string, error) {
+	image, err := c.client.CommitContainer(docker.CommitContainerOptions{
+		Container:  id,
+		Repository: repo,
+		Tag:        tag,
+		Message:    message,
+		Author:     author,
+	})
+	if err != nil {
+		return "", err
+	}
+	return image.ID, nil
+}
+
+// PipeToStdin attaches to the container's stdin and copies in to it until in
+// is exhausted. The detach key sequence is always the daemon's default,
+// Ctrl-P Ctrl-Q, as the vendored docker client's AttachToContainerOptions
+// has no DetachKeys to override it with.
+func (c *Client) PipeToStdin(id string, in io.Reader) error {
+	return c.PipeToStdinWithContext(context.Background(), id, in)
+}
+
+// PipeToStdinWithContext is like PipeToStdin but detaches and returns
+// ctx.Err() if ctx is done before in is exhausted.
+func (c *Client) PipeToStdinWithContext(ctx context.Context, id string, in io.Reader) error {
+	return c.attachWithContext(ctx, docker.AttachToContainerOptions{
+		Container:   id,
+		InputStream: in,
+		Stdin:       true,
+		Stream:      true,
+	})
+}
+
+// ContainerLogs writes the container's stdout and stderr logs to out.
+func (c *Client) ContainerLogs(id string, out io.Writer) error {
+	return c.ContainerLogsWithContext(context.Background(), id, out)
+}
+
+// ContainerLogsWithContext is like ContainerLogs but detaches and returns
+// ctx.Err() if ctx is done before all the logs have been written.
+func (c *Client) ContainerLogsWithContext(ctx context.Context, id string, out io.Writer) error {
+	return c.attachWithContext(ctx, docker.AttachToContainerOptions{
+		Container:    id,
+		OutputStream: out,
+		ErrorStream:  out,
+		Stdout:       true,
+		Stderr:       true,
+		Logs:         true,
+	})
+}
+
+// attachWithContext attaches to a container using opts and blocks until the
+// attach finishes or ctx is done, in which case the attach is closed before
+// returning.
+func (c *Client) attachWithContext(ctx context.Context, opts docker.AttachToContainerOptions) error {
+	cw, err := c.client.AttachToContainerNonBlocking(opts)
+	if err != nil {
+		return err
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cw.Wait()
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		cw.Close()
+		<-errCh
+		return ctx.Err()
+	}
+}
+
+// WaitContainer blocks until the container exits and returns its exit code.
+func (c *Client) WaitContainer(id string) (int, error) {
+	exitCode, err := c.client.WaitContainer(id)
+	return exitCode, containerError(id, err)
+}
+
+// WaitContainerWithContext is like WaitContainer but returns ctx.Err() if ctx
+// is done before the container exits. Rather than holding a wait request open
+// it polls the container's state, so nothing is left running after it
+// returns.
+func (c *Client) WaitContainerWithContext(ctx context.Context, id string) (int, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+		container, err := c.client.InspectContainerWithContext(id, ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			return 0, containerError(id, err)
+		}
+		if !container.State.Running && !container.State.FinishedAt.IsZero() {
+			return container.State.ExitCode, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// IpAddr returns the IP address of the container. For containers which are
+// only on user-defined networks this is their address on the network they
+// were started on, or failing that the first of their networks by name.
+func (c *Client) IpAddr(id string) (string, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return "", containerError(id, err)
+	}
+	return ipAddr(container), nil
+}
+
+// ipAddr returns the IP address of the container as described by IpAddr.
+func ipAddr(container *docker.Container) string {
+	if container.NetworkSettings == nil {
+		return ""
+	}
+	if container.NetworkSettings.IPAddress == "" {
+		if network, ok := primaryNetwork(container); ok {
+			return network.IPAddress
+		}
+	}
+	return container.NetworkSettings.IPAddress
+}
+
+// IpAddrOnNetwork returns the IP address of the container on network. It's
+// an error if the container isn't attached to network.
+func (c *Client) IpAddrOnNetwork(id, network string) (string, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return "", containerError(id, err)
+	}
+	if container.NetworkSettings != nil {
+		if n, ok := container.NetworkSettings.Networks[network]; ok {
+			return n.IPAddress, nil
+		}
+	}
+	return "", fmt.Errorf("container %s is not attached to network %q", id, network)
+}
+
+// GetAllIPs returns the container's IP address on each of its networks,
+// keyed by network name.
+func (c *Client) GetAllIPs(id string) (map[string]string, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return nil, containerError(id, err)
+	}
+	result := make(map[string]string)
+	if container.NetworkSettings != nil {
+		for name, network := range container.NetworkSettings.Networks {
+			if network.IPAddress != "" {
+				result[name] = network.IPAddress
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetMACAddress returns the MAC address of the container. Like IpAddr, for
+// containers which are only on user-defined networks this is their address
+// on their primary network. It's an error if the container isn't running.
+func (c *Client) GetMACAddress(id string) (string, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return "", containerError(id, err)
+	}
+	if !container.State.Running {
+		return "", fmt.Errorf("container %s is not running", id)
+	}
+	if container.NetworkSettings == nil {
+		return "", nil
+	}
+	if container.NetworkSettings.MacAddress == "" {
+		if network, ok := primaryNetwork(container); ok {
+			return network.MacAddress, nil
+		}
+	}
+	return container.NetworkSettings.MacAddress, nil
+}
+
+// GetPortBindings returns the host ports that the container's published
+// ports are bound to, keyed by container port, e.g. "80/tcp". This is how
+// the ports docker picks for ephemeral bindings are discovered.
+func (c *Client) GetPortBindings(id string) (map[string][]docker.PortBinding, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return nil, containerError(id, err)
+	}
+	result := make(map[string][]docker.PortBinding)
+	if container.NetworkSettings != nil {
+		for port, bindings := range container.NetworkSettings.Ports {
+			if len(bindings) > 0 {
+				result[string(port)] = bindings
+			}
+		}
+	}
+	return result, nil
+}
+
+// primaryNetwork returns the network that the container was started on, or
+// failing that the first of its networks by name.
+func primaryNetwork(container *docker.Container) (docker.ContainerNetwork, bool) {
+	networks := container.NetworkSettings.Networks
+	if container.HostConfig != nil {
+		if network, ok := networks[container.HostConfig.NetworkMode]; ok {
+			return network, true
+		}
+	}
+	var names []string
+	for name := range networks {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return docker.ContainerNetwork{}, false
+	}
+	sort.Strings(names)
+	return networks[names[0]], true
+}
+
+// NameInUseError is returned when creating a container with a name that
+// another container already has. Its Cause is ErrContainerAlreadyExists.
+type NameInUseError struct {
+	Name string
+}
+
+func (e *NameInUseError) Error() string {
+	return fmt.Sprintf("container name %q is already in use", e.Name)
+}
+
+// isDockerError returns true if err is an error from the docker daemon whose
+// message contains msg.
+func isDockerError(err error, msg string) bool {
+	dockerErr, ok := err.(*docker.Error)
+	return ok && strings.Contains(dockerErr.Message, msg)
+}
@@ -0,0 +1,221 @@
+package container
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// getTestClient returns a Client for tests which need a docker daemon, the
+// test is skipped if there isn't one available.
+func getTestClient(t *testing.T) *Client {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c, err := NewClient()
+	require.NoError(t, err)
+	if err := c.client.Ping(); err != nil {
+		t.Skipf("Skipping integration tests, docker is unavailable: %v", err)
+	}
+	return c
+}
+
+func TestEnvList(t *testing.T) {
+	require.Equal(t, 0, len(envList(nil)))
+	require.Equal(t, []string{"A=1", "B=", "C=x=y"}, envList(map[string]string{
+		"C": "x=y",
+		"A": "1",
+		"B": "",
+	}))
+}
+
+func TestDockerHost(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping unix socket tests on windows")
+	}
+	for _, key := range []string{"DOCKER_HOST", "XDG_RUNTIME_DIR"} {
+		defer os.Setenv(key, os.Getenv(key))
+	}
+	runtimeDir, err := ioutil.TempDir("", "container-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(runtimeDir)
+	require.NoError(t, os.Setenv("DOCKER_HOST", ""))
+	require.NoError(t, os.Setenv("XDG_RUNTIME_DIR", runtimeDir))
+	require.Equal(t, defaultDockerHost, dockerHost())
+	socket := filepath.Join(runtimeDir, "docker.sock")
+	require.NoError(t, ioutil.WriteFile(socket, nil, 0600))
+	require.Equal(t, "unix://"+socket, dockerHost())
+	require.NoError(t, os.Setenv("DOCKER_HOST", "tcp://localhost:2376"))
+	require.Equal(t, "tcp://localhost:2376", dockerHost())
+}
+
+func TestTLSFile(t *testing.T) {
+	defer os.Setenv("DOCKER_CERT_FILE", os.Getenv("DOCKER_CERT_FILE"))
+	certPath, err := ioutil.TempDir("", "container-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(certPath)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(certPath, "cert.pem"), nil, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(certPath, "client.crt"), nil, 0600))
+	require.NoError(t, os.Setenv("DOCKER_CERT_FILE", ""))
+	path, err := tlsFile(certPath, "DOCKER_CERT_FILE", "cert.pem")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(certPath, "cert.pem"), path)
+	require.NoError(t, os.Setenv("DOCKER_CERT_FILE", "client.crt"))
+	path, err = tlsFile(certPath, "DOCKER_CERT_FILE", "cert.pem")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(certPath, "client.crt"), path)
+	require.NoError(t, os.Setenv("DOCKER_CERT_FILE", "missing.crt"))
+	_, err = tlsFile(certPath, "DOCKER_CERT_FILE", "cert.pem")
+	require.YesError(t, err)
+}
+
+func TestValidateUpdate(t *testing.T) {
+	require.NoError(t, validateUpdate(docker.UpdateContainerOptions{}))
+	require.NoError(t, validateUpdate(docker.UpdateContainerOptions{Memory: 1 << 20, MemorySwap: -1}))
+	require.YesError(t, validateUpdate(docker.UpdateContainerOptions{Memory: -1}))
+	require.YesError(t, validateUpdate(docker.UpdateContainerOptions{CPUQuota: -1}))
+	require.YesError(t, validateUpdate(docker.UpdateContainerOptions{MemorySwap: -2}))
+}
+
+func TestPrimaryNetwork(t *testing.T) {
+	container := &docker.Container{
+		NetworkSettings: &docker.NetworkSettings{
+			Networks: map[string]docker.ContainerNetwork{
+				"b": {IPAddress: "10.0.1.2"},
+				"a": {IPAddress: "10.0.0.2"},
+			},
+		},
+		HostConfig: &docker.HostConfig{NetworkMode: "b"},
+	}
+	network, ok := primaryNetwork(container)
+	require.True(t, ok)
+	require.Equal(t, "10.0.1.2", network.IPAddress)
+	container.HostConfig.NetworkMode = "default"
+	network, ok = primaryNetwork(container)
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.2", network.IPAddress)
+	container.NetworkSettings.Networks = nil
+	_, ok = primaryNetwork(container)
+	require.False(t, ok)
+}
+
+func TestIpAddrUserDefinedNetwork(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.EnsureImage("busybox:latest"))
+	network := fmt.Sprintf("container-test-%d", time.Now().UnixNano())
+	networkID, err := c.CreateNetwork(network, "")
+	require.NoError(t, err)
+	defer c.RemoveNetwork(networkID)
+	id, err := c.StartContainerWithOptions(StartOptions{
+		Image:       "busybox:latest",
+		Command:     []string{"sleep", "60"},
+		NetworkMode: network,
+	})
+	require.NoError(t, err)
+	defer c.RemoveContainer(id, true)
+	ip, err := c.IpAddr(id)
+	require.NoError(t, err)
+	require.NotEqual(t, "", ip)
+	ipOnNetwork, err := c.IpAddrOnNetwork(id, network)
+	require.NoError(t, err)
+	require.Equal(t, ip, ipOnNetwork)
+	_, err = c.IpAddrOnNetwork(id, "bridge")
+	require.YesError(t, err)
+}
+
+// fakeDockerClient is a DockerClient whose containers all have state. Calling
+// any method other than InspectContainer panics.
+type fakeDockerClient struct {
+	DockerClient
+	state docker.State
+}
+
+func (f *fakeDockerClient) InspectContainer(id string) (*docker.Container, error) {
+	return &docker.Container{ID: id, State: f.state}, nil
+}
+
+func TestGetExitCodeFakeClient(t *testing.T) {
+	fake := &fakeDockerClient{state: docker.State{Running: true}}
+	c := NewClientFromDockerClient(fake)
+	_, err := c.GetExitCode("id")
+	require.YesError(t, err)
+	fake.state = docker.State{ExitCode: 3}
+	exitCode, err := c.GetExitCode("id")
+	require.NoError(t, err)
+	require.Equal(t, 3, exitCode)
+}
+
+// fakeListClient is a DockerClient which lists and inspects containers.
+// Calling any other method panics.
+type fakeListClient struct {
+	DockerClient
+	containers []docker.APIContainers
+	listed     bool
+}
+
+func (f *fakeListClient) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	f.listed = true
+	return f.containers, nil
+}
+
+// InspectContainer resolves id like the daemon, but without a useful error
+// for ambiguous prefixes.
+func (f *fakeListClient) InspectContainer(id string) (*docker.Container, error) {
+	resolved, err := resolveContainerID(f.containers, id)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, &docker.NoSuchContainer{ID: id}
+		}
+		return nil, &docker.Error{Status: http.StatusInternalServerError, Message: "multiple IDs found with provided prefix"}
+	}
+	return &docker.Container{ID: resolved}, nil
+}
+
+func TestResolveContainerID(t *testing.T) {
+	fake := &fakeListClient{containers: []docker.APIContainers{
+		{ID: "abc123", Names: []string{"/worker"}},
+		{ID: "abd456", Names: []string{"/db", "/worker/db"}},
+		{ID: "f00", Names: []string{"/abc"}},
+		{ID: "cafe", Names: []string{"/f00"}},
+	}}
+	c := NewClientFromDockerClient(fake)
+	for name, expected := range map[string]string{
+		"worker": "abc123",
+		"db":     "abd456",
+		"abd":    "abd456",
+		// Full IDs come before names, and names before prefixes.
+		"f00": "f00",
+		"abc": "f00",
+	} {
+		id, err := c.ResolveContainerID(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, id)
+	}
+	require.False(t, fake.listed)
+
+	_, err := c.ResolveContainerID("missing")
+	require.YesError(t, err)
+	require.True(t, IsNotFound(err))
+	for _, name := range []string{"ab", ""} {
+		_, err := c.ResolveContainerID(name)
+		require.YesError(t, err)
+		require.False(t, IsNotFound(err))
+	}
+}
+
+func TestAttachDetachKeysUnsupported(t *testing.T) {
+	// PipeToStdin and StartInteractive can't take a DetachKeys option until
+	// the vendored docker client supports it. This fails once it's upgraded,
+	// as a reminder to add it.
+	_, ok := reflect.TypeOf(docker.AttachToContainerOptions{}).FieldByName("DetachKeys")
+	require.False(t, ok)
+}
@@ -0,0 +1,279 @@
+package container
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// CopyToContainer extracts the tar stream content into destPath in the
+// container. destPath must already exist in the container.
+func (c *Client) CopyToContainer(id, destPath string, content io.Reader) error {
+	if err := c.client.UploadToContainer(id, docker.UploadToContainerOptions{
+		InputStream: content,
+		Path:        destPath,
+	}); err != nil {
+		return copyError(id, destPath, err)
+	}
+	return nil
+}
+
+// CopyPathToContainer copies the file or directory hostPath on the host into
+// destPath in the container, e.g. copying "/tmp/config" to "/etc" creates
+// "/etc/config".
+func (c *Client) CopyPathToContainer(id, hostPath, destPath string) error {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeTar(w, hostPath))
+	}()
+	err := c.CopyToContainer(id, destPath, r)
+	// Unblock writeTar if the upload failed before reading everything.
+	r.Close()
+	return err
+}
+
+// CopyFromContainer writes a tar stream of the file or directory srcPath in
+// the container to dest. The stream isn't buffered, so it's suitable for
+// large files.
+func (c *Client) CopyFromContainer(id, srcPath string, dest io.Writer) error {
+	if err := c.client.DownloadFromContainer(id, docker.DownloadFromContainerOptions{
+		OutputStream: dest,
+		Path:         srcPath,
+	}); err != nil {
+		return copyError(id, srcPath, err)
+	}
+	return nil
+}
+
+// copyError returns the error for err, returned by the docker client when
+// copying to or from path in the container id. The daemon returns a 404 both
+// when the container doesn't exist and when path doesn't exist in it, so
+// they're told apart by its message.
+func copyError(id, path string, err error) error {
+	dockerErr, ok := err.(*docker.Error)
+	if !ok || dockerErr.Status != http.StatusNotFound {
+		return err
+	}
+	if strings.Contains(dockerErr.Message, "No such container") {
+		return containerError(id, err)
+	}
+	return &causeError{
+		cause: ErrPathNotFound,
+		msg:   fmt.Sprintf("path %s doesn't exist in container %s", path, id),
+	}
+}
+
+// CopyFromContainerToDir copies the file or directory srcPath in the
+// container into hostDir on the host, e.g. copying "/pfs/out" to "/tmp"
+// creates "/tmp/out".
+func (c *Client) CopyFromContainerToDir(id, srcPath, hostDir string) error {
+	r, w := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := extractTar(r, hostDir)
+		// Unblock the download if extraction failed before reading
+		// everything.
+		r.CloseWithError(err)
+		errCh <- err
+	}()
+	err := c.CopyFromContainer(id, srcPath, w)
+	w.CloseWithError(err)
+	if extractErr := <-errCh; err == nil {
+		err = extractErr
+	}
+	return err
+}
+
+// ExportContainer writes a tar stream of the container's entire filesystem
+// to out. Unlike CommitContainer this doesn't create an image, and the
+// stream isn't buffered so it's suitable for large filesystems.
+func (c *Client) ExportContainer(id string, out io.Writer) error {
+	return containerError(id, c.client.ExportContainer(docker.ExportContainerOptions{
+		ID:           id,
+		OutputStream: out,
+	}))
+}
+
+// writeTar writes a tar stream containing the file or directory at path to
+// w. Entries are named relative to path's parent directory.
+func writeTar(w io.Writer, path string) error {
+	tw := tar.NewWriter(w)
+	base := filepath.Dir(path)
+	if err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(base, file)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	}); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractTar extracts the tar stream r into dir. Entries can't be written
+// outside of dir, either by their names or through symlinks, and links can't
+// point outside of it.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !withinDir(dir, path) {
+			return fmt.Errorf("tar entry %s is outside of %s", header.Name, dir)
+		}
+		if err := checkNoSymlinks(dir, path); err != nil {
+			return err
+		}
+		mode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, mode); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := extractFile(tr, path, mode); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := validateSymlink(dir, path, header.Linkname); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, path); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			// Hard link targets are named relative to the root of the
+			// archive.
+			target := filepath.Join(dir, filepath.FromSlash(header.Linkname))
+			if !withinDir(dir, target) {
+				return fmt.Errorf("tar entry %s links to %s, which is outside of %s", header.Name, header.Linkname, dir)
+			}
+			if err := checkNoSymlinks(dir, target); err != nil {
+				return err
+			}
+			if err := os.Link(target, path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// withinDir returns true if path is dir or is inside of it, without
+// resolving symlinks.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// checkNoSymlinks returns an error if path, or any of its parents below dir,
+// is a symlink, since creating or opening path would then follow it,
+// possibly to outside of dir.
+func checkNoSymlinks(dir, path string) error {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return err
+	}
+	current := dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "." {
+			continue
+		}
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			// Nothing below current exists yet.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("can't extract %s, %s is a symlink", path, current)
+		}
+	}
+	return nil
+}
+
+// validateSymlink returns an error if the symlink at path to link would point
+// outside of dir. Targets which already exist are checked with symlinks
+// resolved.
+func validateSymlink(dir, path, link string) error {
+	link = filepath.FromSlash(link)
+	if filepath.IsAbs(link) {
+		return fmt.Errorf("symlink %s has an absolute target %s", path, link)
+	}
+	target := filepath.Join(filepath.Dir(path), link)
+	if !withinDir(dir, target) {
+		return fmt.Errorf("symlink %s points to %s, which is outside of %s", path, link, dir)
+	}
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		// The target doesn't exist yet, so there's nothing to resolve.
+		return nil
+	}
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if !withinDir(resolvedDir, resolved) {
+		return fmt.Errorf("symlink %s points to %s, which resolves to outside of %s", path, link, dir)
+	}
+	return nil
+}
+
+// extractFile writes r to a new file at path with permissions mode.
+func extractFile(r io.Reader, path string, mode os.FileMode) (retErr error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	_, err = io.Copy(f, r)
+	return err
+}
@@ -0,0 +1,157 @@
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestTarRoundTrip(t *testing.T) {
+	src, err := ioutil.TempDir("", "container-tar-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "out", "dir"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "out", "file"), []byte("foo"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "out", "dir", "file"), []byte("bar"), 0600))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTar(&buf, filepath.Join(src, "out")))
+
+	dest, err := ioutil.TempDir("", "container-tar-dest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dest)
+	require.NoError(t, extractTar(&buf, dest))
+	data, err := ioutil.ReadFile(filepath.Join(dest, "out", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(data))
+	data, err = ioutil.ReadFile(filepath.Join(dest, "out", "dir", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(data))
+	info, err := os.Stat(filepath.Join(dest, "out", "dir", "file"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+// tarEntry is an entry written to a tar stream by writeTarEntries.
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func writeTarEntries(t *testing.T, entries ...tarEntry) *bytes.Buffer {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Linkname: entry.linkname,
+			Mode:     0644,
+			Size:     int64(len(entry.content)),
+		}))
+		_, err := tw.Write([]byte(entry.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func TestExtractTarLinks(t *testing.T) {
+	dest, err := ioutil.TempDir("", "container-tar-dest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dest)
+	require.NoError(t, extractTar(writeTarEntries(t,
+		tarEntry{name: "out/..file", typeflag: tar.TypeReg, content: "foo"},
+		tarEntry{name: "out/symlink", typeflag: tar.TypeSymlink, linkname: "..file"},
+		tarEntry{name: "out/hardlink", typeflag: tar.TypeLink, linkname: "out/..file"},
+	), dest))
+	for _, name := range []string{"..file", "symlink", "hardlink"} {
+		data, err := ioutil.ReadFile(filepath.Join(dest, "out", name))
+		require.NoError(t, err)
+		require.Equal(t, "foo", string(data))
+	}
+}
+
+func TestExtractTarSlip(t *testing.T) {
+	outside, err := ioutil.TempDir("", "container-tar-outside")
+	require.NoError(t, err)
+	defer os.RemoveAll(outside)
+	for _, entries := range [][]tarEntry{
+		{{name: "../file", typeflag: tar.TypeReg, content: "foo"}},
+		{
+			{name: "out/link", typeflag: tar.TypeSymlink, linkname: outside},
+			{name: "out/link/file", typeflag: tar.TypeReg, content: "foo"},
+		},
+		{{name: "out/link", typeflag: tar.TypeSymlink, linkname: "../../file"}},
+		{{name: "out/link", typeflag: tar.TypeLink, linkname: "../file"}},
+		// Links inside of dir can't be written through either.
+		{
+			{name: "out/dir/", typeflag: tar.TypeDir},
+			{name: "out/link", typeflag: tar.TypeSymlink, linkname: "dir"},
+			{name: "out/link/file", typeflag: tar.TypeReg, content: "foo"},
+		},
+	} {
+		dest, err := ioutil.TempDir("", "container-tar-dest")
+		require.NoError(t, err)
+		defer os.RemoveAll(dest)
+		require.YesError(t, extractTar(writeTarEntries(t, entries...), dest))
+	}
+
+	// A symlink that already exists in dir isn't followed.
+	dest, err := ioutil.TempDir("", "container-tar-dest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dest)
+	require.NoError(t, os.Symlink(outside, filepath.Join(dest, "out")))
+	require.YesError(t, extractTar(writeTarEntries(t,
+		tarEntry{name: "out/file", typeflag: tar.TypeReg, content: "foo"},
+	), dest))
+	_, err = os.Lstat(filepath.Join(outside, "file"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestExportContainerMissing(t *testing.T) {
+	err := NewClientFromDockerClient(&fakeMissingContainerClient{}).ExportContainer("missing", &bytes.Buffer{})
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+}
+
+// fakeCopyClient is a DockerClient whose copies fail with a 404 and the
+// daemon's message.
+type fakeCopyClient struct {
+	DockerClient
+	message string
+}
+
+func (f *fakeCopyClient) UploadToContainer(id string, opts docker.UploadToContainerOptions) error {
+	return &docker.Error{Status: http.StatusNotFound, Message: f.message}
+}
+
+func (f *fakeCopyClient) DownloadFromContainer(id string, opts docker.DownloadFromContainerOptions) error {
+	return &docker.Error{Status: http.StatusNotFound, Message: f.message}
+}
+
+func TestCopyNotFound(t *testing.T) {
+	c := NewClientFromDockerClient(&fakeCopyClient{message: "No such container: missing"})
+	err := c.CopyToContainer("missing", "/tmp", &bytes.Buffer{})
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+	err = c.CopyFromContainer("missing", "/tmp", &bytes.Buffer{})
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+
+	c = NewClientFromDockerClient(&fakeCopyClient{message: "Could not find the file /tmp/missing in container worker"})
+	err = c.CopyToContainer("worker", "/tmp/missing", &bytes.Buffer{})
+	require.Equal(t, ErrPathNotFound, Cause(err))
+	require.True(t, strings.Contains(err.Error(), "/tmp/missing"))
+	err = c.CopyFromContainer("worker", "/tmp/missing", &bytes.Buffer{})
+	require.Equal(t, ErrPathNotFound, Cause(err))
+	require.True(t, strings.Contains(err.Error(), "/tmp/missing"))
+	require.True(t, IsNotFound(err))
+}
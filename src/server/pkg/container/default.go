@@ -0,0 +1,971 @@
+package container
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+var (
+	defaultClient     *Client
+	defaultClientErr  error
+	defaultClientOnce sync.Once
+)
+
+// getDefaultClient returns the Client used by the package level functions,
+// creating it on first use.
+func getDefaultClient() (*Client, error) {
+	defaultClientOnce.Do(func() {
+		defaultClient, defaultClientErr = NewClient()
+	})
+	return defaultClient, defaultClientErr
+}
+
+// RawStartContainer calls RawStartContainer on the default Client.
+func RawStartContainer(opts docker.CreateContainerOptions) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.RawStartContainer(opts)
+}
+
+// StartContainer calls StartContainer on the default Client.
+func StartContainer(image string, command []string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.StartContainer(image, command)
+}
+
+// StopContainer calls StopContainer on the default Client.
+func StopContainer(id string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.StopContainer(id)
+}
+
+// StopContainerTimeout calls StopContainerTimeout on the default Client.
+func StopContainerTimeout(id string, timeout uint) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.StopContainerTimeout(id, timeout)
+}
+
+// KillContainer calls KillContainer on the default Client.
+func KillContainer(id string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.KillContainer(id)
+}
+
+// KillContainerSignal calls KillContainerSignal on the default Client.
+func KillContainerSignal(id string, signal string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.KillContainerSignal(id, signal)
+}
+
+// RemoveContainer calls RemoveContainer on the default Client.
+func RemoveContainer(id string, force bool) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RemoveContainer(id, force)
+}
+
+// RemoveContainerAndVolumes calls RemoveContainerAndVolumes on the default
+// Client.
+func RemoveContainerAndVolumes(id string, force bool) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RemoveContainerAndVolumes(id, force)
+}
+
+// PullImage calls PullImage on the default Client.
+func PullImage(image string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullImage(image)
+}
+
+// PipeToStdin calls PipeToStdin on the default Client.
+func PipeToStdin(id string, in io.Reader) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PipeToStdin(id, in)
+}
+
+// ContainerLogs calls ContainerLogs on the default Client.
+func ContainerLogs(id string, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogs(id, out)
+}
+
+// WaitContainer calls WaitContainer on the default Client.
+func WaitContainer(id string) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.WaitContainer(id)
+}
+
+// IpAddr calls IpAddr on the default Client.
+func IpAddr(id string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.IpAddr(id)
+}
+
+// PipeToStdinWithContext calls PipeToStdinWithContext on the default Client.
+func PipeToStdinWithContext(ctx context.Context, id string, in io.Reader) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PipeToStdinWithContext(ctx, id, in)
+}
+
+// ContainerLogsWithContext calls ContainerLogsWithContext on the default
+// Client.
+func ContainerLogsWithContext(ctx context.Context, id string, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsWithContext(ctx, id, out)
+}
+
+// WaitContainerWithContext calls WaitContainerWithContext on the default
+// Client.
+func WaitContainerWithContext(ctx context.Context, id string) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.WaitContainerWithContext(ctx, id)
+}
+
+// ListContainers calls ListContainers on the default Client.
+func ListContainers(opts ListOptions) ([]docker.APIContainers, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListContainers(opts)
+}
+
+// RestartContainer calls RestartContainer on the default Client.
+func RestartContainer(id string, timeout uint) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RestartContainer(id, timeout)
+}
+
+// PauseContainer calls PauseContainer on the default Client.
+func PauseContainer(id string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PauseContainer(id)
+}
+
+// UnpauseContainer calls UnpauseContainer on the default Client.
+func UnpauseContainer(id string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.UnpauseContainer(id)
+}
+
+// ExecInContainer calls ExecInContainer on the default Client.
+func ExecInContainer(id string, cmd []string, out io.Writer) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.ExecInContainer(id, cmd, out)
+}
+
+// StartContainerWithEnv calls StartContainerWithEnv on the default Client.
+func StartContainerWithEnv(image string, command []string, env map[string]string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.StartContainerWithEnv(image, command, env)
+}
+
+// StartContainerWithOptions calls StartContainerWithOptions on the default
+// Client.
+func StartContainerWithOptions(opts StartOptions) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.StartContainerWithOptions(opts)
+}
+
+// StartContainerWithPorts calls StartContainerWithPorts on the default Client.
+func StartContainerWithPorts(opts StartOptions) (string, map[docker.Port][]docker.PortBinding, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.StartContainerWithPorts(opts)
+}
+
+// StartNamedContainer calls StartNamedContainer on the default Client.
+func StartNamedContainer(name, image string, command []string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.StartNamedContainer(name, image, command)
+}
+
+// PullImageAuth calls PullImageAuth on the default Client.
+func PullImageAuth(image string, auth docker.AuthConfiguration) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullImageAuth(image, auth)
+}
+
+// PullImageFromDockerConfig calls PullImageFromDockerConfig on the default
+// Client.
+func PullImageFromDockerConfig(image string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullImageFromDockerConfig(image)
+}
+
+// PullImageProgress calls PullImageProgress on the default Client.
+func PullImageProgress(image string, progress io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullImageProgress(image, progress)
+}
+
+// PullImageRetry calls PullImageRetry on the default Client.
+func PullImageRetry(image string, attempts int, initialBackoff time.Duration) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullImageRetry(image, attempts, initialBackoff)
+}
+
+// ImageExists calls ImageExists on the default Client.
+func ImageExists(image string) (bool, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return false, err
+	}
+	return c.ImageExists(image)
+}
+
+// EnsureImage calls EnsureImage on the default Client.
+func EnsureImage(image string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.EnsureImage(image)
+}
+
+// RemoveImage calls RemoveImage on the default Client.
+func RemoveImage(image string, force bool) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RemoveImage(image, force)
+}
+
+// BuildImage calls BuildImage on the default Client.
+func BuildImage(opts BuildOptions) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.BuildImage(opts)
+}
+
+// PushImage calls PushImage on the default Client.
+func PushImage(image string, auth docker.AuthConfiguration) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PushImage(image, auth)
+}
+
+// PushImageProgress calls PushImageProgress on the default Client.
+func PushImageProgress(image string, auth docker.AuthConfiguration, progress io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PushImageProgress(image, auth, progress)
+}
+
+// TagImage calls TagImage on the default Client.
+func TagImage(source, target string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.TagImage(source, target)
+}
+
+// CommitContainer calls CommitContainer on the default Client.
+func CommitContainer(id string, repo, tag string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.CommitContainer(id, repo, tag)
+}
+
+// CommitContainerMessage calls CommitContainerMessage on the default Client.
+func CommitContainerMessage(id string, repo, tag, message, author string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.CommitContainerMessage(id, repo, tag, message, author)
+}
+
+// CopyToContainer calls CopyToContainer on the default Client.
+func CopyToContainer(id, destPath string, content io.Reader) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.CopyToContainer(id, destPath, content)
+}
+
+// CopyPathToContainer calls CopyPathToContainer on the default Client.
+func CopyPathToContainer(id, hostPath, destPath string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.CopyPathToContainer(id, hostPath, destPath)
+}
+
+// CopyFromContainer calls CopyFromContainer on the default Client.
+func CopyFromContainer(id, srcPath string, dest io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.CopyFromContainer(id, srcPath, dest)
+}
+
+// CopyFromContainerToDir calls CopyFromContainerToDir on the default Client.
+func CopyFromContainerToDir(id, srcPath, hostDir string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.CopyFromContainerToDir(id, srcPath, hostDir)
+}
+
+// FollowContainerLogs calls FollowContainerLogs on the default Client.
+func FollowContainerLogs(ctx context.Context, id string, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.FollowContainerLogs(ctx, id, out)
+}
+
+// ContainerLogsTail calls ContainerLogsTail on the default Client.
+func ContainerLogsTail(id string, lines int, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsTail(id, lines, out)
+}
+
+// ContainerLogsWithOptions calls ContainerLogsWithOptions on the default
+// Client.
+func ContainerLogsWithOptions(ctx context.Context, id string, opts LogsOptions) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsWithOptions(ctx, id, opts)
+}
+
+// ContainerLogsSince calls ContainerLogsSince on the default Client.
+func ContainerLogsSince(id string, since time.Time, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsSince(id, since, out)
+}
+
+// ContainerLogsSinceDuration calls ContainerLogsSinceDuration on the default
+// Client.
+func ContainerLogsSinceDuration(id string, d time.Duration, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsSinceDuration(id, d, out)
+}
+
+// ContainerLogsUntil calls ContainerLogsUntil on the default Client.
+func ContainerLogsUntil(id string, until time.Time, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsUntil(id, until, out)
+}
+
+// ContainerLogsSplit calls ContainerLogsSplit on the default Client.
+func ContainerLogsSplit(id string, stdout, stderr io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsSplit(id, stdout, stderr)
+}
+
+// ContainerLogsTimestamps calls ContainerLogsTimestamps on the default Client.
+func ContainerLogsTimestamps(id string, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ContainerLogsTimestamps(id, out)
+}
+
+// GetContainerStats calls GetContainerStats on the default Client.
+func GetContainerStats(id string) (*docker.Stats, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetContainerStats(id)
+}
+
+// StreamContainerStats calls StreamContainerStats on the default Client.
+func StreamContainerStats(ctx context.Context, id string) (<-chan *docker.Stats, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.StreamContainerStats(ctx, id)
+}
+
+// ContainerState calls ContainerState on the default Client.
+func ContainerState(id string) (*State, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.ContainerState(id)
+}
+
+// GetExitCode calls GetExitCode on the default Client.
+func GetExitCode(id string) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.GetExitCode(id)
+}
+
+// Events calls Events on the default Client.
+func Events(ctx context.Context) (<-chan *docker.APIEvents, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.Events(ctx)
+}
+
+// ContainerEvents calls ContainerEvents on the default Client.
+func ContainerEvents(ctx context.Context, id string) (<-chan *docker.APIEvents, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.ContainerEvents(ctx, id)
+}
+
+// RenameContainer calls RenameContainer on the default Client.
+func RenameContainer(id, newName string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RenameContainer(id, newName)
+}
+
+// WaitForHealthy calls WaitForHealthy on the default Client.
+func WaitForHealthy(ctx context.Context, id string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.WaitForHealthy(ctx, id)
+}
+
+// StopThenKill calls StopThenKill on the default Client.
+func StopThenKill(id string, grace time.Duration) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.StopThenKill(id, grace)
+}
+
+// IpAddrOnNetwork calls IpAddrOnNetwork on the default Client.
+func IpAddrOnNetwork(id, network string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.IpAddrOnNetwork(id, network)
+}
+
+// GetAllIPs calls GetAllIPs on the default Client.
+func GetAllIPs(id string) (map[string]string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetAllIPs(id)
+}
+
+// GetMACAddress calls GetMACAddress on the default Client.
+func GetMACAddress(id string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.GetMACAddress(id)
+}
+
+// GetPortBindings calls GetPortBindings on the default Client.
+func GetPortBindings(id string) (map[string][]docker.PortBinding, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetPortBindings(id)
+}
+
+// StartInteractive calls StartInteractive on the default Client.
+func StartInteractive(image string, command []string, in io.Reader, out io.Writer) (string, docker.CloseWaiter, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.StartInteractive(image, command, in, out)
+}
+
+// ResizeTTY calls ResizeTTY on the default Client.
+func ResizeTTY(id string, height, width int) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ResizeTTY(id, height, width)
+}
+
+// ExecInteractive calls ExecInteractive on the default Client.
+func ExecInteractive(id string, cmd []string, in io.Reader, out io.Writer) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.ExecInteractive(id, cmd, in, out)
+}
+
+// PullAllTags calls PullAllTags on the default Client.
+func PullAllTags(repo string, auth docker.AuthConfiguration) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullAllTags(repo, auth)
+}
+
+// PullAllTagsProgress calls PullAllTagsProgress on the default Client.
+func PullAllTagsProgress(repo string, auth docker.AuthConfiguration, progress io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullAllTagsProgress(repo, auth, progress)
+}
+
+// WaitContainerResult calls WaitContainerResult on the default Client.
+func WaitContainerResult(id string) (WaitResult, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return WaitResult{}, err
+	}
+	return c.WaitContainerResult(id)
+}
+
+// StopContainers calls StopContainers on the default Client.
+func StopContainers(ids []string, timeout uint) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.StopContainers(ids, timeout)
+}
+
+// PullImages calls PullImages on the default Client.
+func PullImages(images []string, concurrency int) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.PullImages(images, concurrency)
+}
+
+// RunAndRemove calls RunAndRemove on the default Client.
+func RunAndRemove(opts StartOptions, out io.Writer) (int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.RunAndRemove(opts, out)
+}
+
+// WasOOMKilled calls WasOOMKilled on the default Client.
+func WasOOMKilled(id string) (bool, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return false, err
+	}
+	return c.WasOOMKilled(id)
+}
+
+// ExportContainer calls ExportContainer on the default Client.
+func ExportContainer(id string, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ExportContainer(id, out)
+}
+
+// SaveImage calls SaveImage on the default Client.
+func SaveImage(images []string, out io.Writer) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.SaveImage(images, out)
+}
+
+// LoadImage calls LoadImage on the default Client.
+func LoadImage(in io.Reader) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.LoadImage(in)
+}
+
+// PruneContainers calls PruneContainers on the default Client.
+func PruneContainers(filters map[string][]string) (int64, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.PruneContainers(filters)
+}
+
+// PruneImages calls PruneImages on the default Client.
+func PruneImages(danglingOnly bool) (int64, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return 0, err
+	}
+	return c.PruneImages(danglingOnly)
+}
+
+// ListImages calls ListImages on the default Client.
+func ListImages(all bool) ([]docker.APIImages, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListImages(all)
+}
+
+// InspectImage calls InspectImage on the default Client.
+func InspectImage(image string) (*docker.Image, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.InspectImage(image)
+}
+
+// GetImageHistory calls GetImageHistory on the default Client.
+func GetImageHistory(image string) ([]docker.ImageHistory, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetImageHistory(image)
+}
+
+// CreateNetwork calls CreateNetwork on the default Client.
+func CreateNetwork(name string, driver string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.CreateNetwork(name, driver)
+}
+
+// RemoveNetwork calls RemoveNetwork on the default Client.
+func RemoveNetwork(id string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RemoveNetwork(id)
+}
+
+// ConnectNetwork calls ConnectNetwork on the default Client.
+func ConnectNetwork(networkID, containerID string, aliases []string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ConnectNetwork(networkID, containerID, aliases)
+}
+
+// DisconnectNetwork calls DisconnectNetwork on the default Client.
+func DisconnectNetwork(networkID, containerID string, force bool) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.DisconnectNetwork(networkID, containerID, force)
+}
+
+// CreateVolume calls CreateVolume on the default Client.
+func CreateVolume(name, driver string) (*docker.Volume, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateVolume(name, driver)
+}
+
+// RemoveVolume calls RemoveVolume on the default Client.
+func RemoveVolume(name string, force bool) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.RemoveVolume(name, force)
+}
+
+// ListVolumes calls ListVolumes on the default Client.
+func ListVolumes() ([]docker.Volume, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListVolumes()
+}
+
+// ContainerTop calls ContainerTop on the default Client.
+func ContainerTop(id string, psArgs string) (docker.TopResult, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return docker.TopResult{}, err
+	}
+	return c.ContainerTop(id, psArgs)
+}
+
+// ContainerDiff calls ContainerDiff on the default Client.
+func ContainerDiff(id string) ([]docker.Change, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.ContainerDiff(id)
+}
+
+// UpdateContainerResources calls UpdateContainerResources on the default
+// Client.
+func UpdateContainerResources(id string, res docker.UpdateContainerOptions) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.UpdateContainerResources(id, res)
+}
+
+// StartContainerEntrypoint calls StartContainerEntrypoint on the default
+// Client.
+func StartContainerEntrypoint(image string, entrypoint, command []string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.StartContainerEntrypoint(image, entrypoint, command)
+}
+
+// WaitForPort calls WaitForPort on the default Client.
+func WaitForPort(ctx context.Context, id string, containerPort int) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.WaitForPort(ctx, id, containerPort)
+}
+
+// WaitForLogLine calls WaitForLogLine on the default Client.
+func WaitForLogLine(ctx context.Context, id string, pattern *regexp.Regexp) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.WaitForLogLine(ctx, id, pattern)
+}
+
+// EventsFiltered calls EventsFiltered on the default Client.
+func EventsFiltered(ctx context.Context, filters map[string][]string) (<-chan *docker.APIEvents, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.EventsFiltered(ctx, filters)
+}
+
+// StartContainerResult calls StartContainerResult on the default Client.
+func StartContainerResult(opts StartOptions) (*StartResult, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.StartContainerResult(opts)
+}
+
+// RunContainer calls RunContainer on the default Client.
+func RunContainer(image string, command []string) (string, int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", 0, err
+	}
+	return c.RunContainer(image, command)
+}
+
+// RunContainerWithOptions calls RunContainerWithOptions on the default Client.
+func RunContainerWithOptions(image string, command []string, opts RunOptions) (string, int, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", 0, err
+	}
+	return c.RunContainerWithOptions(image, command, opts)
+}
+
+// ExecInteractiveTTY calls ExecInteractiveTTY on the default Client.
+func ExecInteractiveTTY(id string, cmd []string, in io.Reader, out io.Writer) (string, docker.CloseWaiter, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", nil, err
+	}
+	return c.ExecInteractiveTTY(id, cmd, in, out)
+}
+
+// ResizeExecTTY calls ResizeExecTTY on the default Client.
+func ResizeExecTTY(execID string, height, width int) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ResizeExecTTY(execID, height, width)
+}
+
+// ExecDetached calls ExecDetached on the default Client.
+func ExecDetached(id string, cmd []string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.ExecDetached(id, cmd)
+}
+
+// InspectExec calls InspectExec on the default Client.
+func InspectExec(execID string) (*docker.ExecInspect, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.InspectExec(execID)
+}
+
+// ContainerExists calls ContainerExists on the default Client.
+func ContainerExists(idOrName string) (bool, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return false, err
+	}
+	return c.ContainerExists(idOrName)
+}
+
+// ResolveContainerID calls ResolveContainerID on the default Client.
+func ResolveContainerID(name string) (string, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.ResolveContainerID(name)
+}
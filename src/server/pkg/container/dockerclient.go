@@ -0,0 +1,71 @@
+package container
+
+import (
+	"context"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// DockerClient is the subset of *docker.Client's methods that Client uses.
+// Tests can implement it with a fake to use a Client without a docker daemon.
+type DockerClient interface {
+	Ping() error
+
+	CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error)
+	StartContainer(id string, hostConfig *docker.HostConfig) error
+	StopContainer(id string, timeout uint) error
+	KillContainer(opts docker.KillContainerOptions) error
+	RestartContainer(id string, timeout uint) error
+	UpdateContainer(id string, opts docker.UpdateContainerOptions) error
+	PauseContainer(id string) error
+	UnpauseContainer(id string) error
+	RenameContainer(opts docker.RenameContainerOptions) error
+	RemoveContainer(opts docker.RemoveContainerOptions) error
+	WaitContainer(id string) (int, error)
+	InspectContainer(id string) (*docker.Container, error)
+	InspectContainerWithContext(id string, ctx context.Context) (*docker.Container, error)
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
+	CommitContainer(opts docker.CommitContainerOptions) (*docker.Image, error)
+	TopContainer(id string, psArgs string) (docker.TopResult, error)
+	ContainerChanges(id string) ([]docker.Change, error)
+
+	AttachToContainerNonBlocking(opts docker.AttachToContainerOptions) (docker.CloseWaiter, error)
+	ResizeContainerTTY(id string, height, width int) error
+	Logs(opts docker.LogsOptions) error
+	Stats(opts docker.StatsOptions) error
+	UploadToContainer(id string, opts docker.UploadToContainerOptions) error
+	DownloadFromContainer(id string, opts docker.DownloadFromContainerOptions) error
+	ExportContainer(opts docker.ExportContainerOptions) error
+
+	CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error)
+	StartExec(id string, opts docker.StartExecOptions) error
+	StartExecNonBlocking(id string, opts docker.StartExecOptions) (docker.CloseWaiter, error)
+	InspectExec(id string) (*docker.ExecInspect, error)
+	ResizeExecTTY(id string, height, width int) error
+
+	PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error
+	PushImage(opts docker.PushImageOptions, auth docker.AuthConfiguration) error
+	BuildImage(opts docker.BuildImageOptions) error
+	TagImage(name string, opts docker.TagImageOptions) error
+	InspectImage(name string) (*docker.Image, error)
+	ImageHistory(name string) ([]docker.ImageHistory, error)
+	ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error)
+	RemoveImageExtended(name string, opts docker.RemoveImageOptions) error
+	ExportImages(opts docker.ExportImagesOptions) error
+	LoadImage(opts docker.LoadImageOptions) error
+
+	CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error)
+	RemoveNetwork(id string) error
+	NetworkInfo(id string) (*docker.Network, error)
+	ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+	DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+
+	CreateVolume(opts docker.CreateVolumeOptions) (*docker.Volume, error)
+	RemoveVolume(name string) error
+	ListVolumes(opts docker.ListVolumesOptions) ([]docker.Volume, error)
+
+	AddEventListener(listener chan<- *docker.APIEvents) error
+	RemoveEventListener(listener chan *docker.APIEvents) error
+}
+
+var _ DockerClient = &docker.Client{}
@@ -0,0 +1,108 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+var (
+	// ErrContainerNotFound is the cause, as returned by Cause, of errors
+	// returned when operating on a container that doesn't exist.
+	ErrContainerNotFound = errors.New("container not found")
+	// ErrImageNotFound is the cause of errors returned when operating on an
+	// image that doesn't exist locally.
+	ErrImageNotFound = errors.New("image not found")
+	// ErrPathNotFound is the cause of errors returned when copying to or from
+	// a path that doesn't exist in a container.
+	ErrPathNotFound = errors.New("path not found")
+	// ErrContainerAlreadyExists is the cause of the *NameInUseError returned
+	// when creating a container with a name that's already in use.
+	ErrContainerAlreadyExists = errors.New("container already exists")
+)
+
+// Cause returns the sentinel error above that caused err, or err itself if it
+// wasn't caused by one of them.
+func Cause(err error) error {
+	switch err := err.(type) {
+	case *causeError:
+		return err.cause
+	case *NameInUseError:
+		return ErrContainerAlreadyExists
+	}
+	return err
+}
+
+// IsNotFound returns true if err means that a container, image or path in a
+// container doesn't exist. It recognizes both this package's errors and those returned by the
+// docker client, so it can be used to make cleanup idempotent.
+func IsNotFound(err error) bool {
+	switch Cause(err) {
+	case ErrContainerNotFound, ErrImageNotFound, ErrPathNotFound:
+		return true
+	}
+	return isContainerNotFound(err) || isImageNotFound(err)
+}
+
+// IsAlreadyExists returns true if err means that a container with the same
+// name already exists.
+func IsAlreadyExists(err error) bool {
+	return Cause(err) == ErrContainerAlreadyExists || err == docker.ErrContainerAlreadyExists
+}
+
+// causeError is an error with its own message whose cause is one of the
+// sentinel errors above.
+type causeError struct {
+	cause error
+	msg   string
+}
+
+func (e *causeError) Error() string {
+	return e.msg
+}
+
+// containerError returns an error whose cause is ErrContainerNotFound if err
+// means that the container id doesn't exist, and err otherwise.
+func containerError(id string, err error) error {
+	if isContainerNotFound(err) {
+		return &causeError{
+			cause: ErrContainerNotFound,
+			msg:   fmt.Sprintf("container %s doesn't exist", id),
+		}
+	}
+	return err
+}
+
+// imageError returns an error whose cause is ErrImageNotFound if err means
+// that image doesn't exist locally, and err otherwise.
+func imageError(image string, err error) error {
+	if isImageNotFound(err) {
+		return &causeError{
+			cause: ErrImageNotFound,
+			msg:   fmt.Sprintf("image %s doesn't exist locally", image),
+		}
+	}
+	return err
+}
+
+// isContainerNotFound returns true if err is a docker client error meaning
+// that a container doesn't exist.
+func isContainerNotFound(err error) bool {
+	if _, ok := err.(*docker.NoSuchContainer); ok {
+		return true
+	}
+	dockerErr, ok := err.(*docker.Error)
+	return ok && dockerErr.Status == http.StatusNotFound
+}
+
+// isImageNotFound returns true if err is a docker client error meaning that
+// an image doesn't exist.
+func isImageNotFound(err error) bool {
+	if err == docker.ErrNoSuchImage {
+		return true
+	}
+	dockerErr, ok := err.(*docker.Error)
+	return ok && dockerErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,49 @@
+package container
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestIsNotFound(t *testing.T) {
+	for _, err := range []error{
+		ErrContainerNotFound,
+		ErrImageNotFound,
+		ErrPathNotFound,
+		containerError("abc", &docker.NoSuchContainer{ID: "abc"}),
+		imageError("busybox:latest", docker.ErrNoSuchImage),
+		&docker.NoSuchContainer{ID: "abc"},
+		docker.ErrNoSuchImage,
+		&docker.Error{Status: http.StatusNotFound},
+	} {
+		require.True(t, IsNotFound(err))
+	}
+	for _, err := range []error{
+		nil,
+		fmt.Errorf("connection refused"),
+		&docker.Error{Status: http.StatusConflict},
+		&docker.ContainerNotRunning{ID: "abc"},
+	} {
+		require.False(t, IsNotFound(err))
+	}
+}
+
+func TestContainerError(t *testing.T) {
+	err := containerError("abc", &docker.NoSuchContainer{ID: "abc"})
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+	require.Equal(t, "container abc doesn't exist", err.Error())
+	require.NoError(t, containerError("abc", nil))
+	conflict := &docker.Error{Status: http.StatusConflict}
+	require.Equal(t, error(conflict), containerError("abc", conflict))
+}
+
+func TestIsAlreadyExists(t *testing.T) {
+	require.True(t, IsAlreadyExists(&NameInUseError{Name: "worker"}))
+	require.Equal(t, ErrContainerAlreadyExists, Cause(&NameInUseError{Name: "worker"}))
+	require.True(t, IsAlreadyExists(docker.ErrContainerAlreadyExists))
+	require.False(t, IsAlreadyExists(ErrContainerNotFound))
+}
@@ -0,0 +1,142 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// Events returns a channel which receives the docker daemon's events until
+// ctx is done, at which point the channel is closed.
+func (c *Client) Events(ctx context.Context) (<-chan *docker.APIEvents, error) {
+	return c.events(ctx, nil)
+}
+
+// ContainerEvents is like Events but only receives events for the container
+// with the full id id.
+func (c *Client) ContainerEvents(ctx context.Context, id string) (<-chan *docker.APIEvents, error) {
+	return c.events(ctx, func(event *docker.APIEvents) bool {
+		return event.Actor.ID == id || event.ID == id
+	})
+}
+
+// EventsFiltered is like Events but only receives events matching filters,
+// which use the daemon's event filter keys: "container" (ID or name), "image",
+// "label" ("key" or "key=value"), "type" and "event". An event must match
+// every key, and any one of a key's values.
+//
+// The vendored docker client can't pass filters to the daemon, so they're
+// applied as events are received.
+func (c *Client) EventsFiltered(ctx context.Context, filters map[string][]string) (<-chan *docker.APIEvents, error) {
+	for key := range filters {
+		if _, ok := eventFilters[key]; !ok {
+			return nil, fmt.Errorf("invalid event filter %q", key)
+		}
+	}
+	return c.events(ctx, func(event *docker.APIEvents) bool {
+		return matchEventFilters(event, filters)
+	})
+}
+
+// eventFilters maps the supported event filter keys to a function reporting
+// whether an event matches one of the key's values.
+var eventFilters = map[string]func(event *docker.APIEvents, value string) bool{
+	"container": func(event *docker.APIEvents, value string) bool {
+		return (event.Type == "" || event.Type == "container") &&
+			(event.Actor.ID == value || event.ID == value || event.Actor.Attributes["name"] == value)
+	},
+	"image": func(event *docker.APIEvents, value string) bool {
+		return event.From == value || event.Actor.Attributes["image"] == value ||
+			(event.Type == "image" && event.Actor.ID == value)
+	},
+	"label": func(event *docker.APIEvents, value string) bool {
+		parts := strings.SplitN(value, "=", 2)
+		actual, ok := event.Actor.Attributes[parts[0]]
+		return ok && (len(parts) == 1 || actual == parts[1])
+	},
+	"type": func(event *docker.APIEvents, value string) bool {
+		return event.Type == value
+	},
+	"event": func(event *docker.APIEvents, value string) bool {
+		return event.Action == value || event.Status == value
+	},
+}
+
+// matchEventFilters reports whether event matches filters.
+func matchEventFilters(event *docker.APIEvents, filters map[string][]string) bool {
+	for key, values := range filters {
+		if len(values) == 0 {
+			continue
+		}
+		matched := false
+		for _, value := range values {
+			if eventFilters[key](event, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// events returns a channel which receives the daemon's events for which
+// match returns true, or all events if match is nil, until ctx is done.
+func (c *Client) events(ctx context.Context, match func(*docker.APIEvents) bool) (<-chan *docker.APIEvents, error) {
+	listener := make(chan *docker.APIEvents)
+	if err := c.client.AddEventListener(listener); err != nil {
+		return nil, err
+	}
+	result := make(chan *docker.APIEvents)
+	go func() {
+		defer close(result)
+		for {
+			select {
+			case event, ok := <-listener:
+				if !ok {
+					// The client closes listeners if it loses its
+					// connection to the daemon.
+					return
+				}
+				if match != nil && !match(event) {
+					continue
+				}
+				select {
+				case result <- event:
+				case <-ctx.Done():
+					c.removeEventListener(listener)
+					return
+				}
+			case <-ctx.Done():
+				c.removeEventListener(listener)
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
+// removeEventListener removes listener from the client. The client blocks
+// sending events to listeners while holding the lock that removal needs, so
+// listener is drained until it's removed.
+func (c *Client) removeEventListener(listener chan *docker.APIEvents) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case _, ok := <-listener:
+				if !ok {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	c.client.RemoveEventListener(listener)
+	close(done)
+}
@@ -0,0 +1,42 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestMatchEventFilters(t *testing.T) {
+	event := &docker.APIEvents{
+		Action: "die",
+		Type:   "container",
+		Actor: docker.APIActor{
+			ID: "abc123",
+			Attributes: map[string]string{
+				"name":  "worker",
+				"image": "busybox:latest",
+				"owner": "supervisor",
+			},
+		},
+	}
+	require.True(t, matchEventFilters(event, nil))
+	require.True(t, matchEventFilters(event, map[string][]string{"container": {"abc123"}}))
+	require.True(t, matchEventFilters(event, map[string][]string{"container": {"worker"}}))
+	require.False(t, matchEventFilters(event, map[string][]string{"container": {"other"}}))
+	require.True(t, matchEventFilters(event, map[string][]string{"label": {"owner"}}))
+	require.True(t, matchEventFilters(event, map[string][]string{"label": {"owner=supervisor"}}))
+	require.False(t, matchEventFilters(event, map[string][]string{"label": {"owner=someone"}}))
+	require.True(t, matchEventFilters(event, map[string][]string{"event": {"start", "die"}}))
+	require.True(t, matchEventFilters(event, map[string][]string{"image": {"busybox:latest"}}))
+	require.False(t, matchEventFilters(event, map[string][]string{
+		"label": {"owner=supervisor"},
+		"type":  {"network"},
+	}))
+}
+
+func TestEventsFilteredInvalidFilter(t *testing.T) {
+	_, err := NewClientFromDockerClient(nil).EventsFiltered(context.Background(), map[string][]string{"colour": {"red"}})
+	require.YesError(t, err)
+}
@@ -0,0 +1,109 @@
+package container
+
+import (
+	"io"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// ExecInContainer runs cmd inside the running container, writing its stdout
+// and stderr to out, and returns cmd's exit code.
+func (c *Client) ExecInContainer(id string, cmd []string, out io.Writer) (int, error) {
+	return c.exec(id, cmd, nil, out)
+}
+
+// ExecInteractive is like ExecInContainer but also copies in to cmd's stdin.
+// When in is exhausted cmd's stdin is closed, so cmd sees EOF. No TTY is
+// allocated since with one the daemon doesn't close a process's input when
+// the client's input ends, which would leave cmd hanging.
+func (c *Client) ExecInteractive(id string, cmd []string, in io.Reader, out io.Writer) (int, error) {
+	return c.exec(id, cmd, in, out)
+}
+
+// ExecInteractiveTTY is like ExecInteractive but allocates a TTY for cmd and
+// doesn't wait for it to exit. It returns cmd's exec ID, which ResizeExecTTY
+// takes, and a CloseWaiter whose Wait blocks until cmd exits, after which
+// InspectExec returns its exit code. With a TTY cmd's stdout and stderr are a
+// single stream, and its stdin isn't closed when in is exhausted, so cmd has
+// to exit by itself.
+func (c *Client) ExecInteractiveTTY(id string, cmd []string, in io.Reader, out io.Writer) (string, docker.CloseWaiter, error) {
+	exec, err := c.client.CreateExec(docker.CreateExecOptions{
+		Container:    id,
+		Cmd:          cmd,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          true,
+	})
+	if err != nil {
+		return "", nil, containerError(id, err)
+	}
+	cw, err := c.client.StartExecNonBlocking(exec.ID, docker.StartExecOptions{
+		InputStream:  in,
+		OutputStream: out,
+		ErrorStream:  out,
+		Tty:          true,
+		RawTerminal:  true,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return exec.ID, cw, nil
+}
+
+// ResizeExecTTY sets the size of the TTY of an exec started by
+// ExecInteractiveTTY, which should be kept in sync with the terminal attached
+// to it.
+func (c *Client) ResizeExecTTY(execID string, height, width int) error {
+	return c.client.ResizeExecTTY(execID, height, width)
+}
+
+// ExecDetached starts cmd inside the running container without waiting for it
+// to exit and returns its exec ID. cmd's output is discarded, InspectExec
+// reports whether it's still running and its exit code once it's exited.
+func (c *Client) ExecDetached(id string, cmd []string) (string, error) {
+	exec, err := c.client.CreateExec(docker.CreateExecOptions{
+		Container: id,
+		Cmd:       cmd,
+	})
+	if err != nil {
+		return "", containerError(id, err)
+	}
+	if err := c.client.StartExec(exec.ID, docker.StartExecOptions{Detach: true}); err != nil {
+		return "", err
+	}
+	return exec.ID, nil
+}
+
+// InspectExec returns the state of an exec, including whether it's running,
+// its pid and, once it's exited, its exit code.
+func (c *Client) InspectExec(execID string) (*docker.ExecInspect, error) {
+	return c.client.InspectExec(execID)
+}
+
+// exec runs cmd inside the running container and returns its exit code. cmd's
+// stdin is only attached if in is non-nil.
+func (c *Client) exec(id string, cmd []string, in io.Reader, out io.Writer) (int, error) {
+	exec, err := c.client.CreateExec(docker.CreateExecOptions{
+		Container:    id,
+		Cmd:          cmd,
+		AttachStdin:  in != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, containerError(id, err)
+	}
+	if err := c.client.StartExec(exec.ID, docker.StartExecOptions{
+		InputStream:  in,
+		OutputStream: out,
+		ErrorStream:  out,
+	}); err != nil {
+		return 0, err
+	}
+	inspect, err := c.client.InspectExec(exec.ID)
+	if err != nil {
+		return 0, err
+	}
+	return inspect.ExitCode, nil
+}
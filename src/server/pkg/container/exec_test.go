@@ -0,0 +1,47 @@
+package container
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestExecDetached(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.EnsureImage("busybox:latest"))
+	id, err := c.StartContainer("busybox:latest", []string{"sleep", "60"})
+	require.NoError(t, err)
+	defer c.RemoveContainer(id, true)
+	execID, err := c.ExecDetached(id, []string{"sh", "-c", "sleep 1; exit 4"})
+	require.NoError(t, err)
+	inspect, err := c.InspectExec(execID)
+	require.NoError(t, err)
+	require.True(t, inspect.Running)
+	for inspect.Running {
+		time.Sleep(100 * time.Millisecond)
+		inspect, err = c.InspectExec(execID)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, inspect.ExitCode)
+}
+
+// fakeExecClient is a DockerClient without any containers to exec in.
+type fakeExecClient struct {
+	DockerClient
+}
+
+func (f *fakeExecClient) CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error) {
+	return nil, &docker.NoSuchContainer{ID: opts.Container}
+}
+
+func TestExecMissingContainer(t *testing.T) {
+	c := NewClientFromDockerClient(&fakeExecClient{})
+	var out bytes.Buffer
+	_, err := c.ExecInContainer("missing", []string{"true"}, &out)
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+	_, err = c.ExecInteractive("missing", []string{"cat"}, &bytes.Buffer{}, &out)
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+}
@@ -0,0 +1,421 @@
+package container
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// PullImage pulls image, if image has neither a tag nor a digest "latest" is
+// pulled.
+func (c *Client) PullImage(image string) error {
+	return c.PullImageAuth(image, docker.AuthConfiguration{})
+}
+
+// PullImageAuth is like PullImage but authenticates to the registry with
+// auth.
+func (c *Client) PullImageAuth(image string, auth docker.AuthConfiguration) error {
+	return c.pullImage(image, auth, nil)
+}
+
+// PullImageProgress is like PullImage but writes the pull's progress, as
+// displayed by the docker CLI, to progress. It still blocks until the pull is
+// done.
+func (c *Client) PullImageProgress(image string, progress io.Writer) error {
+	return c.pullImage(image, docker.AuthConfiguration{}, progress)
+}
+
+// PullImageRetry is like PullImage but retries up to attempts times if the
+// pull fails with a transient error, such as a network timeout, waiting
+// initialBackoff before the first retry and exponentially longer after that.
+// Permanent errors, such as a missing image or bad credentials, are returned
+// immediately.
+func (c *Client) PullImageRetry(image string, attempts int, initialBackoff time.Duration) error {
+	return retryTransient(func() error {
+		return c.PullImage(image)
+	}, attempts, initialBackoff)
+}
+
+// PullImages pulls each of images, running up to concurrency pulls at once.
+// References to the same image, such as "busybox" and "busybox:latest", are
+// only pulled once.
+func (c *Client) PullImages(images []string, concurrency int) error {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, image := range images {
+		image = canonicalImage(image)
+		if !seen[image] {
+			seen[image] = true
+			unique = append(unique, image)
+		}
+	}
+	if err := forEach(unique, concurrency, c.PullImage); err != nil {
+		return fmt.Errorf("error pulling images: %v", err)
+	}
+	return nil
+}
+
+// pullImage pulls image authenticating with auth and writing progress to
+// progress, which may be nil.
+func (c *Client) pullImage(image string, auth docker.AuthConfiguration, progress io.Writer) error {
+	repository, tag, digest := parseImageReference(image)
+	switch {
+	case digest != "":
+		// The API takes the digest in place of the tag.
+		tag = digest
+	case tag == "":
+		tag = "latest"
+	}
+	return c.client.PullImage(
+		docker.PullImageOptions{
+			Repository:   repository,
+			Tag:          tag,
+			OutputStream: progress,
+		},
+		auth,
+	)
+}
+
+// PullAllTags pulls every tag of repo, which mustn't have a tag or digest,
+// like "docker pull --all-tags".
+func (c *Client) PullAllTags(repo string, auth docker.AuthConfiguration) error {
+	return c.PullAllTagsProgress(repo, auth, nil)
+}
+
+// PullAllTagsProgress is like PullAllTags but writes the pull's progress, as
+// displayed by the docker CLI, to progress.
+func (c *Client) PullAllTagsProgress(repo string, auth docker.AuthConfiguration, progress io.Writer) error {
+	repository, tag, digest := parseImageReference(repo)
+	if tag != "" || digest != "" {
+		return fmt.Errorf("can't pull all tags of %s, it names a single image", repo)
+	}
+	// The API pulls every tag if none is given.
+	return c.client.PullImage(
+		docker.PullImageOptions{
+			Repository:   repository,
+			OutputStream: progress,
+		},
+		auth,
+	)
+}
+
+// PullImageFromDockerConfig is like PullImage but authenticates to the
+// registry with the credentials for it in the docker config file (usually
+// ~/.docker/config.json). If there's no config file, or it has no credentials
+// for the registry, the image is pulled anonymously.
+func (c *Client) PullImageFromDockerConfig(image string) error {
+	if !dockerConfigExists() {
+		return c.PullImage(image)
+	}
+	auths, err := docker.NewAuthConfigurationsFromDockerCfg()
+	if err != nil {
+		return fmt.Errorf("error reading docker config: %v", err)
+	}
+	repository, _, _ := parseImageReference(image)
+	auth, _ := findAuth(auths, registryHost(repository))
+	return c.PullImageAuth(image, auth)
+}
+
+// dockerConfigExists returns true if any of the docker config files that
+// docker.NewAuthConfigurationsFromDockerCfg reads exist.
+func dockerConfigExists() bool {
+	var paths []string
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "config.json"))
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		paths = append(paths, filepath.Join(home, ".docker", "config.json"), filepath.Join(home, ".dockercfg"))
+	}
+	for _, path := range paths {
+		// Files that exist but can't be read are reported when reading
+		// them.
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerHubHost is the registry host of images that don't name one.
+const dockerHubHost = "index.docker.io"
+
+// registryHost returns the registry host that repository is pulled from. As
+// in the docker CLI, the first path component is only a host if it contains a
+// "." or ":" or is "localhost".
+func registryHost(repository string) string {
+	i := strings.Index(repository, "/")
+	if i < 0 {
+		return dockerHubHost
+	}
+	host := repository[:i]
+	if host != "localhost" && !strings.ContainsAny(host, ".:") {
+		return dockerHubHost
+	}
+	if host == "docker.io" {
+		return dockerHubHost
+	}
+	return host
+}
+
+// findAuth returns the credentials in auths for host. Docker config files key
+// credentials by either a bare host or a URL, so both are checked.
+func findAuth(auths *docker.AuthConfigurations, host string) (docker.AuthConfiguration, bool) {
+	for server, auth := range auths.Configs {
+		server = strings.TrimPrefix(server, "https://")
+		server = strings.TrimPrefix(server, "http://")
+		if i := strings.Index(server, "/"); i >= 0 {
+			server = server[:i]
+		}
+		if server == "docker.io" {
+			server = dockerHubHost
+		}
+		if server == host {
+			return auth, true
+		}
+	}
+	return docker.AuthConfiguration{}, false
+}
+
+// PushImage pushes image to its registry, authenticating with auth. If image
+// has no tag "latest" is pushed.
+func (c *Client) PushImage(image string, auth docker.AuthConfiguration) error {
+	return c.PushImageProgress(image, auth, nil)
+}
+
+// PushImageProgress is like PushImage but writes the push's progress, as
+// displayed by the docker CLI, to progress.
+func (c *Client) PushImageProgress(image string, auth docker.AuthConfiguration, progress io.Writer) error {
+	repository, tag, digest := parseImageReference(image)
+	if digest != "" {
+		return fmt.Errorf("can't push %s, images can't be pushed by digest", image)
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	// The push is streamed, so auth failures are reported as a plain error
+	// rather than an API error with a status code.
+	if err := c.client.PushImage(
+		docker.PushImageOptions{
+			Name:         repository,
+			Tag:          tag,
+			OutputStream: progress,
+		},
+		auth,
+	); err != nil {
+		if auth == (docker.AuthConfiguration{}) && isAuthError(err) {
+			return fmt.Errorf("no credentials given for pushing %s to %s: %v", image, registryHost(repository), err)
+		}
+		return err
+	}
+	return nil
+}
+
+// isAuthError returns true if err is due to the registry rejecting our
+// credentials, or lack thereof.
+func isAuthError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unauthorized") ||
+		strings.Contains(msg, "authentication required") ||
+		strings.Contains(msg, "denied")
+}
+
+// TagImage tags the local image source as target, e.g. tagging "myapp:dev" as
+// "registry:5000/myapp:v1". If target has no tag it's tagged "latest".
+func (c *Client) TagImage(source, target string) error {
+	repository, tag, digest := parseImageReference(target)
+	if digest != "" {
+		return fmt.Errorf("can't tag %s as %s, tags can't contain a digest", source, target)
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	if err := c.client.TagImage(source, docker.TagImageOptions{
+		Repo: repository,
+		Tag:  tag,
+	}); err != nil {
+		return imageError(source, err)
+	}
+	return nil
+}
+
+// ListImages returns the local images along with their tags and digests. If
+// all is true intermediate images are included too.
+func (c *Client) ListImages(all bool) ([]docker.APIImages, error) {
+	return c.client.ListImages(docker.ListImagesOptions{
+		All:     all,
+		Digests: true,
+	})
+}
+
+// InspectImage returns the local image's metadata, such as its config and
+// size. image may be a reference or an ID.
+func (c *Client) InspectImage(image string) (*docker.Image, error) {
+	result, err := c.client.InspectImage(image)
+	if err != nil {
+		return nil, imageError(image, err)
+	}
+	return result, nil
+}
+
+// GetImageHistory returns the history of the local image's layers, newest
+// first, including the commands that created them. image may be a reference
+// or an ID.
+func (c *Client) GetImageHistory(image string) ([]docker.ImageHistory, error) {
+	history, err := c.client.ImageHistory(image)
+	if err != nil {
+		return nil, imageError(image, err)
+	}
+	return history, nil
+}
+
+// ImageExists returns true if image is present locally.
+func (c *Client) ImageExists(image string) (bool, error) {
+	if _, err := c.client.InspectImage(image); err != nil {
+		if isImageNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// EnsureImage pulls image if it isn't present locally. An image ID can't be
+// pulled, so if image is one that isn't present the returned error's cause is
+// ErrImageNotFound.
+func (c *Client) EnsureImage(image string) error {
+	exists, err := c.ImageExists(image)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	if isImageID(image) {
+		return imageError(image, docker.ErrNoSuchImage)
+	}
+	return c.PullImage(image)
+}
+
+// RemoveImage removes image, if force is true it's removed even if containers
+// are using it. Removing an image that doesn't exist is not an error.
+func (c *Client) RemoveImage(image string, force bool) error {
+	if err := c.client.RemoveImageExtended(image, docker.RemoveImageOptions{Force: force}); err != nil {
+		if isImageNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// SaveImage writes a tar stream of images, including their layers and tags,
+// to out. The stream can be loaded into another daemon with LoadImage.
+func (c *Client) SaveImage(images []string, out io.Writer) error {
+	if len(images) == 0 {
+		return fmt.Errorf("no images to save")
+	}
+	return c.client.ExportImages(docker.ExportImagesOptions{
+		Names:        images,
+		OutputStream: out,
+	})
+}
+
+// LoadImage loads the images in the tar stream in, as written by SaveImage or
+// "docker save".
+func (c *Client) LoadImage(in io.Reader) error {
+	return c.client.LoadImage(docker.LoadImageOptions{InputStream: in})
+}
+
+// BuildOptions specifies how BuildImage builds an image.
+type BuildOptions struct {
+	// ContextDir is a directory on the host to use as the build context.
+	ContextDir string
+	// Context is a tar stream to use as the build context, exactly one of
+	// ContextDir and Context must be set.
+	Context io.Reader
+	// Dockerfile is the path of the Dockerfile within the build context, if
+	// empty "Dockerfile" is used.
+	Dockerfile string
+	// Tag names the built image, e.g. "myapp:dev".
+	Tag string
+	// BuildArgs sets build-time variables used by ARG instructions.
+	BuildArgs map[string]string
+	// Output receives the build's output, it may be nil.
+	Output io.Writer
+}
+
+// BuildImage builds an image as specified by opts.
+func (c *Client) BuildImage(opts BuildOptions) error {
+	if (opts.ContextDir == "") == (opts.Context == nil) {
+		return fmt.Errorf("exactly one of ContextDir and Context must be set")
+	}
+	output := opts.Output
+	if output == nil {
+		output = ioutil.Discard
+	}
+	var buildArgs []docker.BuildArg
+	for _, name := range sortedKeys(opts.BuildArgs) {
+		buildArgs = append(buildArgs, docker.BuildArg{Name: name, Value: opts.BuildArgs[name]})
+	}
+	return c.client.BuildImage(docker.BuildImageOptions{
+		Name:           opts.Tag,
+		Dockerfile:     opts.Dockerfile,
+		ContextDir:     opts.ContextDir,
+		InputStream:    opts.Context,
+		BuildArgs:      buildArgs,
+		OutputStream:   output,
+		RmTmpContainer: true,
+	})
+}
+
+// canonicalImage returns image with the tag that PullImage would pull, i.e.
+// "latest" if it has neither a tag nor a digest. Image IDs are returned
+// unchanged.
+func canonicalImage(image string) string {
+	if isImageID(image) {
+		return image
+	}
+	if _, tag, digest := parseImageReference(image); tag == "" && digest == "" {
+		return image + ":latest"
+	}
+	return image
+}
+
+// isImageID returns true if image is an image ID rather than a reference,
+// i.e. a "sha256:" digest or at least the 12 hex characters of a short ID.
+func isImageID(image string) bool {
+	if strings.HasPrefix(image, "sha256:") {
+		return true
+	}
+	if len(image) < 12 || len(image) > 64 {
+		return false
+	}
+	for _, c := range image {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImageReference splits an image reference of the form
+// "[registry[:port]/]name[:tag][@digest]" into its repository, tag and digest,
+// tag and digest are empty if they aren't present.
+func parseImageReference(image string) (repository string, tag string, digest string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+	repository = image
+	// A colon only separates the tag if it's in the last path component,
+	// otherwise it's the port of the registry host.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repository, tag = image[:i], image[i+1:]
+	}
+	return repository, tag, digest
+}
@@ -0,0 +1,187 @@
+package container
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+func TestParseImageReference(t *testing.T) {
+	for _, test := range []struct {
+		image      string
+		repository string
+		tag        string
+		digest     string
+	}{
+		{"ubuntu", "ubuntu", "", ""},
+		{"ubuntu:16.04", "ubuntu", "16.04", ""},
+		{"pachyderm/pachd:1.6.0", "pachyderm/pachd", "1.6.0", ""},
+		{"registry:5000/foo", "registry:5000/foo", "", ""},
+		{"registry:5000/foo:v2", "registry:5000/foo", "v2", ""},
+		{"localhost:5000/myimage:tag", "localhost:5000/myimage", "tag", ""},
+		{"foo@sha256:abc", "foo", "", "sha256:abc"},
+		{"registry:5000/foo:v2@sha256:abc", "registry:5000/foo", "v2", "sha256:abc"},
+	} {
+		repository, tag, digest := parseImageReference(test.image)
+		require.Equal(t, test.repository, repository, test.image)
+		require.Equal(t, test.tag, tag, test.image)
+		require.Equal(t, test.digest, digest, test.image)
+	}
+}
+
+func TestCanonicalImage(t *testing.T) {
+	require.Equal(t, "ubuntu:latest", canonicalImage("ubuntu"))
+	require.Equal(t, "ubuntu:16.04", canonicalImage("ubuntu:16.04"))
+	require.Equal(t, "registry:5000/foo:latest", canonicalImage("registry:5000/foo"))
+	require.Equal(t, "foo@sha256:abc", canonicalImage("foo@sha256:abc"))
+	require.Equal(t, "e7d92cdc71fe", canonicalImage("e7d92cdc71fe"))
+	require.Equal(t, "sha256:e7d92cdc71fe", canonicalImage("sha256:e7d92cdc71fe"))
+	require.Equal(t, "cafe:latest", canonicalImage("cafe"))
+}
+
+func TestRegistryHost(t *testing.T) {
+	require.Equal(t, dockerHubHost, registryHost("ubuntu"))
+	require.Equal(t, dockerHubHost, registryHost("pachyderm/pachd"))
+	require.Equal(t, dockerHubHost, registryHost("docker.io/pachyderm/pachd"))
+	require.Equal(t, "localhost", registryHost("localhost/foo"))
+	require.Equal(t, "registry:5000", registryHost("registry:5000/foo"))
+	require.Equal(t, "gcr.io", registryHost("gcr.io/project/foo"))
+}
+
+func TestFindAuth(t *testing.T) {
+	auths := &docker.AuthConfigurations{
+		Configs: map[string]docker.AuthConfiguration{
+			"https://index.docker.io/v1/": {Username: "hub"},
+			"registry:5000":               {Username: "private"},
+		},
+	}
+	auth, ok := findAuth(auths, dockerHubHost)
+	require.True(t, ok)
+	require.Equal(t, "hub", auth.Username)
+	auth, ok = findAuth(auths, "registry:5000")
+	require.True(t, ok)
+	require.Equal(t, "private", auth.Username)
+	_, ok = findAuth(auths, "gcr.io")
+	require.False(t, ok)
+}
+
+func TestPullImageDigest(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.PullImage("busybox:latest"))
+	image, err := c.client.InspectImage("busybox:latest")
+	require.NoError(t, err)
+	require.True(t, len(image.RepoDigests) > 0)
+	// RepoDigests are of the form "busybox@sha256:..."
+	_, _, digest := parseImageReference(image.RepoDigests[0])
+	require.NoError(t, c.PullImage("busybox@"+digest))
+	pulled, err := c.client.InspectImage("busybox@" + digest)
+	require.NoError(t, err)
+	require.Equal(t, image.ID, pulled.ID)
+	require.OneOfEquals(t, "busybox@"+digest, pulled.RepoDigests)
+}
+
+// fakePullClient is a DockerClient which records the credentials images are
+// pulled with. Pulls fail with errs in order, and then succeed. Calling any
+// method other than PullImage panics.
+type fakePullClient struct {
+	DockerClient
+	errs   []error
+	pulled []docker.AuthConfiguration
+}
+
+func (f *fakePullClient) PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error {
+	f.pulled = append(f.pulled, auth)
+	if len(f.pulled) <= len(f.errs) {
+		return f.errs[len(f.pulled)-1]
+	}
+	return nil
+}
+
+func TestPullImageFromDockerConfigMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "container-docker-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, key := range []string{"DOCKER_CONFIG", "HOME"} {
+		defer os.Setenv(key, os.Getenv(key))
+		require.NoError(t, os.Setenv(key, dir))
+	}
+	fake := &fakePullClient{}
+	require.NoError(t, NewClientFromDockerClient(fake).PullImageFromDockerConfig("busybox:latest"))
+	require.Equal(t, []docker.AuthConfiguration{{}}, fake.pulled)
+
+	// A config file that can't be parsed is still an error.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte("{"), 0600))
+	require.YesError(t, NewClientFromDockerClient(fake).PullImageFromDockerConfig("busybox:latest"))
+}
+
+// fakeMissingImageClient is a DockerClient which fails every image request
+// with a 404, as some daemons do instead of the error the docker client
+// translates to docker.ErrNoSuchImage.
+type fakeMissingImageClient struct {
+	DockerClient
+}
+
+func (f *fakeMissingImageClient) InspectImage(name string) (*docker.Image, error) {
+	return nil, &docker.Error{Status: http.StatusNotFound}
+}
+
+func (f *fakeMissingImageClient) RemoveImageExtended(name string, opts docker.RemoveImageOptions) error {
+	return &docker.Error{Status: http.StatusNotFound}
+}
+
+func TestImageNotFound404(t *testing.T) {
+	c := NewClientFromDockerClient(&fakeMissingImageClient{})
+	exists, err := c.ImageExists("busybox:latest")
+	require.NoError(t, err)
+	require.False(t, exists)
+	require.NoError(t, c.RemoveImage("busybox:latest", false))
+}
+
+// fakeImageRefClient is a DockerClient which records the references images
+// are inspected with and has no images.
+type fakeImageRefClient struct {
+	fakePullClient
+	inspected []string
+}
+
+func (f *fakeImageRefClient) InspectImage(name string) (*docker.Image, error) {
+	f.inspected = append(f.inspected, name)
+	return nil, docker.ErrNoSuchImage
+}
+
+func (f *fakeImageRefClient) ImageHistory(name string) ([]docker.ImageHistory, error) {
+	f.inspected = append(f.inspected, name)
+	return nil, docker.ErrNoSuchImage
+}
+
+func TestEnsureImageID(t *testing.T) {
+	fake := &fakeImageRefClient{}
+	c := NewClientFromDockerClient(fake)
+	// IDs can't be pulled.
+	err := c.EnsureImage("sha256:e7d92cdc71fe")
+	require.Equal(t, ErrImageNotFound, Cause(err))
+	require.Equal(t, 0, len(fake.pulled))
+	require.NoError(t, c.EnsureImage("busybox"))
+	require.Equal(t, 1, len(fake.pulled))
+	require.Equal(t, []string{"sha256:e7d92cdc71fe", "busybox"}, fake.inspected)
+}
+
+func TestInspectImageID(t *testing.T) {
+	fake := &fakeImageRefClient{}
+	_, err := NewClientFromDockerClient(fake).InspectImage("e7d92cdc71fe")
+	require.Equal(t, ErrImageNotFound, Cause(err))
+	require.Equal(t, []string{"e7d92cdc71fe"}, fake.inspected)
+}
+
+func TestGetImageHistoryReference(t *testing.T) {
+	fake := &fakeImageRefClient{}
+	_, err := NewClientFromDockerClient(fake).GetImageHistory("busybox")
+	require.Equal(t, ErrImageNotFound, Cause(err))
+	require.Equal(t, []string{"busybox"}, fake.inspected)
+}
@@ -0,0 +1,214 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// ErrNoHealthcheck is returned when waiting for a container without a
+// healthcheck to become healthy.
+var ErrNoHealthcheck = errors.New("container has no healthcheck")
+
+// State is the part of a container's state that's usually checked after
+// starting or waiting on it.
+type State struct {
+	Running    bool
+	ExitCode   int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	OOMKilled  bool
+	// Health is "starting", "healthy" or "unhealthy" for running containers
+	// with a healthcheck, and empty otherwise.
+	Health string
+}
+
+// ContainerState returns the container's current state.
+func (c *Client) ContainerState(id string) (*State, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return nil, containerError(id, err)
+	}
+	state := &State{
+		Running:    container.State.Running,
+		ExitCode:   container.State.ExitCode,
+		StartedAt:  container.State.StartedAt,
+		FinishedAt: container.State.FinishedAt,
+		OOMKilled:  container.State.OOMKilled,
+	}
+	if state.Running {
+		if state.Health, err = c.containerHealth(container.ID); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// ContainerExists returns true if a container with the ID, unique ID prefix or
+// name idOrName exists, whether or not it's running.
+func (c *Client) ContainerExists(idOrName string) (bool, error) {
+	if _, err := c.client.InspectContainer(idOrName); err != nil {
+		if isContainerNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetExitCode returns the exit code of a container that has already exited,
+// unlike WaitContainer it doesn't block. It's an error to call it on a
+// running container.
+func (c *Client) GetExitCode(id string) (int, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return 0, containerError(id, err)
+	}
+	if container.State.Running {
+		return 0, fmt.Errorf("container %s is still running", id)
+	}
+	return container.State.ExitCode, nil
+}
+
+// WasOOMKilled returns true if the container was killed for running out of
+// memory. This distinguishes it from being killed by a signal, which gives
+// the same exit code.
+func (c *Client) WasOOMKilled(id string) (bool, error) {
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return false, containerError(id, err)
+	}
+	return container.State.OOMKilled, nil
+}
+
+// WaitResult describes how a container exited.
+type WaitResult struct {
+	ExitCode   int
+	OOMKilled  bool
+	FinishedAt time.Time
+	// Error is the daemon's error for the container, if it has one, e.g. if
+	// its command couldn't be run.
+	Error string
+}
+
+// WaitContainerResult is like WaitContainer but returns more about how the
+// container exited than its exit code.
+func (c *Client) WaitContainerResult(id string) (WaitResult, error) {
+	if _, err := c.client.WaitContainer(id); err != nil {
+		return WaitResult{}, containerError(id, err)
+	}
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return WaitResult{}, containerError(id, err)
+	}
+	return WaitResult{
+		ExitCode:   container.State.ExitCode,
+		OOMKilled:  container.State.OOMKilled,
+		FinishedAt: container.State.FinishedAt,
+		Error:      container.State.Error,
+	}, nil
+}
+
+// WaitForHealthy blocks until the container's healthcheck reports that it's
+// healthy. An error is returned if it reports that it's unhealthy or the
+// container exits first, and ErrNoHealthcheck is returned if it has no
+// healthcheck, in which case callers will need to check its readiness some
+// other way.
+func (c *Client) WaitForHealthy(ctx context.Context, id string) error {
+	container, err := c.client.InspectContainerWithContext(id, ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return containerError(id, err)
+	}
+	healthcheck := container.Config.Healthcheck
+	if healthcheck == nil || len(healthcheck.Test) == 0 || healthcheck.Test[0] == "NONE" {
+		return ErrNoHealthcheck
+	}
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+		state, err := c.ContainerState(container.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if !state.Running {
+			return fmt.Errorf("container %s exited with code %d before becoming healthy", id, state.ExitCode)
+		}
+		switch state.Health {
+		case "healthy":
+			return nil
+		case "unhealthy":
+			return fmt.Errorf("container %s is unhealthy", id)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// containerHealth returns the health of the container with the full id id.
+// The vendored client's State has no health field, but the daemon includes
+// the health in the status it lists containers with.
+func (c *Client) containerHealth(id string) (string, error) {
+	containers, err := c.client.ListContainers(docker.ListContainersOptions{
+		All:     true,
+		Filters: map[string][]string{"id": {id}},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, container := range containers {
+		if container.ID == id {
+			return parseHealth(container.Status), nil
+		}
+	}
+	return "", nil
+}
+
+// parseHealth returns the health in a container status as listed by the
+// daemon, e.g. "Up 5 minutes (healthy)".
+func parseHealth(status string) string {
+	switch {
+	case strings.HasSuffix(status, "(health: starting)"):
+		return "starting"
+	case strings.HasSuffix(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.HasSuffix(status, "(healthy)"):
+		return "healthy"
+	}
+	return ""
+}
+
+// ContainerTop lists the processes running in the container, as listed by ps
+// with psArgs, which defaults to "-ef".
+func (c *Client) ContainerTop(id string, psArgs string) (docker.TopResult, error) {
+	if psArgs == "" {
+		psArgs = "-ef"
+	}
+	result, err := c.client.TopContainer(id, psArgs)
+	return result, containerError(id, err)
+}
+
+// ContainerDiff returns the paths in the container's filesystem that have
+// been added, changed or deleted since it was created.
+func (c *Client) ContainerDiff(id string) ([]docker.Change, error) {
+	changes, err := c.client.ContainerChanges(id)
+	if err != nil {
+		return nil, containerError(id, err)
+	}
+	if changes == nil {
+		changes = []docker.Change{}
+	}
+	return changes, nil
+}
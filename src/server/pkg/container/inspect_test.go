@@ -0,0 +1,68 @@
+package container
+
+import (
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestParseHealth(t *testing.T) {
+	require.Equal(t, "starting", parseHealth("Up 2 seconds (health: starting)"))
+	require.Equal(t, "healthy", parseHealth("Up 5 minutes (healthy)"))
+	require.Equal(t, "unhealthy", parseHealth("Up 5 minutes (unhealthy)"))
+	require.Equal(t, "", parseHealth("Up 5 minutes"))
+	require.Equal(t, "", parseHealth("Exited (0) 3 seconds ago"))
+}
+
+func TestWasOOMKilled(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.EnsureImage("busybox:latest"))
+	id, err := c.StartContainerWithOptions(StartOptions{
+		Image: "busybox:latest",
+		// Doubling a string until the shell runs out of memory.
+		Command:    []string{"sh", "-c", "x=x; while true; do x=$x$x; done"},
+		Memory:     8 * 1024 * 1024,
+		MemorySwap: 8 * 1024 * 1024,
+	})
+	require.NoError(t, err)
+	defer c.RemoveContainer(id, true)
+	result, err := c.WaitContainerResult(id)
+	require.NoError(t, err)
+	require.True(t, result.OOMKilled)
+	oomKilled, err := c.WasOOMKilled(id)
+	require.NoError(t, err)
+	require.True(t, oomKilled)
+}
+
+func TestContainerExists(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.EnsureImage("busybox:latest"))
+	id, err := c.StartContainer("busybox:latest", []string{"true"})
+	require.NoError(t, err)
+	exists, err := c.ContainerExists(id)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.NoError(t, c.RemoveContainer(id, true))
+	exists, err = c.ContainerExists(id)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+// fakeMissingContainerClient is a DockerClient without any containers.
+type fakeMissingContainerClient struct {
+	DockerClient
+}
+
+func (f *fakeMissingContainerClient) WaitContainer(id string) (int, error) {
+	return 0, &docker.NoSuchContainer{ID: id}
+}
+
+func (f *fakeMissingContainerClient) ExportContainer(opts docker.ExportContainerOptions) error {
+	return &docker.NoSuchContainer{ID: opts.ID}
+}
+
+func TestWaitContainerResultMissing(t *testing.T) {
+	_, err := NewClientFromDockerClient(&fakeMissingContainerClient{}).WaitContainerResult("missing")
+	require.Equal(t, ErrContainerNotFound, Cause(err))
+}
@@ -0,0 +1,179 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// LogsOptions specifies which of a container's logs ContainerLogsWithOptions
+// gets.
+type LogsOptions struct {
+	// Output receives the container's stdout, and its stderr unless
+	// ErrorOutput is set.
+	Output io.Writer
+	// ErrorOutput receives the container's stderr.
+	ErrorOutput io.Writer
+	// Follow keeps writing new output until the container exits.
+	Follow bool
+	// Tail only gets the last Tail lines, 0 gets all of them.
+	Tail int
+	// Since only gets lines written at or after Since.
+	Since time.Time
+	// Until only gets lines written at or before Until, it can't be combined
+	// with Follow.
+	Until time.Time
+	// Timestamps prefixes each line with the RFC3339Nano time it was
+	// written.
+	Timestamps bool
+}
+
+// ContainerLogsWithOptions gets the container's logs as specified by opts. If
+// ctx is done before all the logs have been written ctx.Err() is returned.
+func (c *Client) ContainerLogsWithOptions(ctx context.Context, id string, opts LogsOptions) error {
+	errorOutput := opts.ErrorOutput
+	if errorOutput == nil {
+		errorOutput = opts.Output
+	}
+	logsOpts := docker.LogsOptions{
+		Context:      ctx,
+		Container:    id,
+		OutputStream: opts.Output,
+		ErrorStream:  errorOutput,
+		Stdout:       true,
+		Stderr:       true,
+		Follow:       opts.Follow,
+		Tail:         "all",
+		Timestamps:   opts.Timestamps,
+	}
+	if opts.Tail > 0 {
+		logsOpts.Tail = strconv.Itoa(opts.Tail)
+	}
+	if !opts.Since.IsZero() {
+		logsOpts.Since = opts.Since.Unix()
+	}
+	if !opts.Until.IsZero() {
+		if opts.Follow {
+			return fmt.Errorf("Until can't be combined with Follow")
+		}
+		// The vendored client can't send until to the daemon, so instead we
+		// get timestamps with each line and filter on them here.
+		logsOpts.Timestamps = true
+		stdout := &untilWriter{w: opts.Output, until: opts.Until, timestamps: opts.Timestamps}
+		stderr := &untilWriter{w: errorOutput, until: opts.Until, timestamps: opts.Timestamps}
+		logsOpts.OutputStream = stdout
+		logsOpts.ErrorStream = stderr
+		defer stdout.Flush()
+		defer stderr.Flush()
+	}
+	err := c.client.Logs(logsOpts)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// FollowContainerLogs writes the container's stdout and stderr logs to out,
+// and keeps writing new output until the container exits or ctx is done, in
+// which case ctx.Err() is returned.
+func (c *Client) FollowContainerLogs(ctx context.Context, id string, out io.Writer) error {
+	return c.ContainerLogsWithOptions(ctx, id, LogsOptions{Output: out, Follow: true})
+}
+
+// ContainerLogsTail writes the last lines lines of the container's stdout and
+// stderr logs to out, a lines of 0 writes all of them.
+func (c *Client) ContainerLogsTail(id string, lines int, out io.Writer) error {
+	return c.ContainerLogsWithOptions(context.Background(), id, LogsOptions{Output: out, Tail: lines})
+}
+
+// ContainerLogsSplit writes the container's stdout logs to stdout and its
+// stderr logs to stderr.
+func (c *Client) ContainerLogsSplit(id string, stdout, stderr io.Writer) error {
+	return c.ContainerLogsWithOptions(context.Background(), id, LogsOptions{Output: stdout, ErrorOutput: stderr})
+}
+
+// ContainerLogsTimestamps writes the container's stdout and stderr logs to
+// out with each line prefixed by the time it was written, like
+// `docker logs -t`.
+func (c *Client) ContainerLogsTimestamps(id string, out io.Writer) error {
+	return c.ContainerLogsWithOptions(context.Background(), id, LogsOptions{Output: out, Timestamps: true})
+}
+
+// ContainerLogsSince writes the container's stdout and stderr logs written
+// since since to out.
+func (c *Client) ContainerLogsSince(id string, since time.Time, out io.Writer) error {
+	return c.ContainerLogsWithOptions(context.Background(), id, LogsOptions{Output: out, Since: since})
+}
+
+// ContainerLogsSinceDuration writes the container's stdout and stderr logs
+// written in the last d to out.
+func (c *Client) ContainerLogsSinceDuration(id string, d time.Duration, out io.Writer) error {
+	return c.ContainerLogsSince(id, time.Now().Add(-d), out)
+}
+
+// ContainerLogsUntil writes the container's stdout and stderr logs written
+// until until to out.
+func (c *Client) ContainerLogsUntil(id string, until time.Time, out io.Writer) error {
+	return c.ContainerLogsWithOptions(context.Background(), id, LogsOptions{Output: out, Until: until})
+}
+
+// untilWriter writes the lines written to it to w, unless the timestamp
+// docker prefixes them with is after until. The timestamp is removed unless
+// timestamps is true. Lines may be split across calls to Write, the final
+// line is written by Flush if it doesn't end in a newline.
+type untilWriter struct {
+	w          io.Writer
+	until      time.Time
+	timestamps bool
+	buf        []byte
+}
+
+func (u *untilWriter) Write(p []byte) (int, error) {
+	u.buf = append(u.buf, p...)
+	for {
+		i := bytes.IndexByte(u.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := u.writeLine(u.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		u.buf = u.buf[i+1:]
+	}
+}
+
+// Flush writes any buffered partial line.
+func (u *untilWriter) Flush() error {
+	if len(u.buf) == 0 {
+		return nil
+	}
+	err := u.writeLine(u.buf)
+	u.buf = nil
+	return err
+}
+
+func (u *untilWriter) writeLine(line []byte) error {
+	i := bytes.IndexByte(line, ' ')
+	if i < 0 {
+		_, err := u.w.Write(line)
+		return err
+	}
+	ts, err := time.Parse(time.RFC3339Nano, string(line[:i]))
+	if err != nil {
+		_, err := u.w.Write(line)
+		return err
+	}
+	if ts.After(u.until) {
+		return nil
+	}
+	if !u.timestamps {
+		line = line[i+1:]
+	}
+	_, err = u.w.Write(line)
+	return err
+}
@@ -0,0 +1,38 @@
+package container
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestUntilWriter(t *testing.T) {
+	var buf bytes.Buffer
+	until, err := time.Parse(time.RFC3339, "2017-06-01T12:00:00Z")
+	require.NoError(t, err)
+	w := &untilWriter{w: &buf, until: until}
+	// Lines are split across writes to check that partial lines are buffered.
+	for _, s := range []string{
+		"2017-06-01T11:59:59.123456789Z fir",
+		"st\n2017-06-01T12:00:00Z second\n2017-06-01T12:00:00.000000001Z ",
+		"third\n2017-06-01T11:00:00Z fourth",
+	} {
+		_, err := w.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.Equal(t, "first\nsecond\n", buf.String())
+	require.NoError(t, w.Flush())
+	require.Equal(t, "first\nsecond\nfourth", buf.String())
+}
+
+func TestUntilWriterTimestamps(t *testing.T) {
+	var buf bytes.Buffer
+	until, err := time.Parse(time.RFC3339, "2017-06-01T12:00:00Z")
+	require.NoError(t, err)
+	w := &untilWriter{w: &buf, until: until, timestamps: true}
+	_, err = w.Write([]byte("2017-06-01T11:59:59Z first\n2017-06-01T12:00:01Z second\n"))
+	require.NoError(t, err)
+	require.Equal(t, "2017-06-01T11:59:59Z first\n", buf.String())
+}
@@ -0,0 +1,73 @@
+package container
+
+import (
+	"fmt"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// CreateNetwork creates a user-defined network and returns its id. If driver
+// is empty the network uses the "bridge" driver. Containers are attached to
+// it with StartOptions.NetworkMode.
+func (c *Client) CreateNetwork(name string, driver string) (string, error) {
+	if driver == "" {
+		driver = "bridge"
+	}
+	network, err := c.client.CreateNetwork(docker.CreateNetworkOptions{
+		Name:           name,
+		Driver:         driver,
+		CheckDuplicate: true,
+	})
+	if err != nil {
+		if err == docker.ErrNetworkAlreadyExists {
+			return "", fmt.Errorf("network %q already exists", name)
+		}
+		return "", err
+	}
+	return network.ID, nil
+}
+
+// RemoveNetwork removes the network. Removing a network that doesn't exist is
+// not an error.
+func (c *Client) RemoveNetwork(id string) error {
+	if err := c.client.RemoveNetwork(id); err != nil {
+		if _, ok := err.(*docker.NoSuchNetwork); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// ConnectNetwork attaches the running container to the network, where other
+// containers can also reach it by the DNS names in aliases. Once it's
+// connected IpAddrOnNetwork and GetAllIPs include its address on the
+// network.
+func (c *Client) ConnectNetwork(networkID, containerID string, aliases []string) error {
+	opts := docker.NetworkConnectionOptions{Container: containerID}
+	if len(aliases) > 0 {
+		opts.EndpointConfig = &docker.EndpointConfig{Aliases: aliases}
+	}
+	return c.client.ConnectNetwork(networkID, opts)
+}
+
+// DisconnectNetwork detaches the container from the network, e.g. to
+// simulate a network partition. If force is true the container is
+// disconnected even if it isn't running, and it's not an error if the
+// container no longer exists, though it still is if the network doesn't.
+func (c *Client) DisconnectNetwork(networkID, containerID string, force bool) error {
+	if err := c.client.DisconnectNetwork(networkID, docker.NetworkConnectionOptions{
+		Container: containerID,
+		Force:     force,
+	}); err != nil {
+		if _, ok := err.(*docker.NoSuchNetworkOrContainer); ok && force {
+			// The daemon doesn't say which of them is missing.
+			if _, err := c.client.NetworkInfo(networkID); err != nil {
+				return err
+			}
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package container
+
+import (
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// fakeNetworkClient is a DockerClient with a single network, which has no
+// containers.
+type fakeNetworkClient struct {
+	DockerClient
+	networkExists bool
+}
+
+func (f *fakeNetworkClient) DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+}
+
+func (f *fakeNetworkClient) NetworkInfo(id string) (*docker.Network, error) {
+	if !f.networkExists {
+		return nil, &docker.NoSuchNetwork{ID: id}
+	}
+	return &docker.Network{ID: id}, nil
+}
+
+func TestDisconnectNetworkMissing(t *testing.T) {
+	fake := &fakeNetworkClient{networkExists: true}
+	c := NewClientFromDockerClient(fake)
+	require.YesError(t, c.DisconnectNetwork("network", "missing", false))
+	require.NoError(t, c.DisconnectNetwork("network", "missing", true))
+
+	fake.networkExists = false
+	err := c.DisconnectNetwork("network", "missing", true)
+	require.YesError(t, err)
+	_, ok := err.(*docker.NoSuchNetwork)
+	require.True(t, ok)
+}
@@ -0,0 +1,174 @@
+package container
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// PruneContainers removes the stopped containers which match filters and
+// returns the disk space reclaimed in bytes. The supported filters are
+// "label", whose values are "key" or "key=value", and "until", whose value
+// is a duration like "24h", an RFC 3339 timestamp or a unix timestamp,
+// limiting pruning to containers created before then.
+//
+// The vendored docker client predates the prune API, so PruneContainers
+// lists and removes the containers itself.
+func (c *Client) PruneContainers(filters map[string][]string) (int64, error) {
+	listFilters := map[string][]string{
+		"status": {"created", "exited", "dead"},
+	}
+	var until time.Time
+	for key, values := range filters {
+		switch key {
+		case "label":
+			listFilters["label"] = values
+		case "until":
+			if len(values) != 1 {
+				return 0, fmt.Errorf("the until filter takes exactly one value")
+			}
+			var err error
+			if until, err = parseUntil(values[0], time.Now()); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("unsupported prune filter %q", key)
+		}
+	}
+	containers, err := c.client.ListContainers(docker.ListContainersOptions{
+		All:     true,
+		Size:    true,
+		Filters: listFilters,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	sizes := make(map[string]int64)
+	for _, container := range containers {
+		if !until.IsZero() && !time.Unix(container.Created, 0).Before(until) {
+			continue
+		}
+		ids = append(ids, container.ID)
+		sizes[container.ID] = container.SizeRw
+	}
+	var reclaimed int64
+	err = forEach(ids, defaultConcurrency, func(id string) error {
+		if err := c.removeContainer(docker.RemoveContainerOptions{ID: id}); err != nil {
+			return err
+		}
+		atomic.AddInt64(&reclaimed, sizes[id])
+		return nil
+	})
+	if err != nil {
+		return reclaimed, fmt.Errorf("error pruning containers: %v", err)
+	}
+	return reclaimed, nil
+}
+
+// parseUntil parses the value of an "until" prune filter, which is either a
+// duration before now, an RFC 3339 timestamp or a unix timestamp.
+func parseUntil(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid until filter %q, it must be a duration or a timestamp", value)
+}
+
+// PruneImages removes the dangling images, those with no tags, which aren't
+// used by containers and returns the disk space reclaimed in bytes. If
+// danglingOnly is false tagged images which aren't used by containers are
+// also removed, like "docker image prune --all".
+//
+// The vendored docker client predates the prune API, so PruneImages removes
+// the images itself. Images used by any container, running or not, are
+// skipped, as are images the daemon refuses to remove, e.g. because other
+// images are built on them. The space reclaimed is the sum of the removed
+// images' sizes, which overcounts layers that were shared with other images.
+func (c *Client) PruneImages(danglingOnly bool) (int64, error) {
+	used, err := c.usedImages()
+	if err != nil {
+		return 0, err
+	}
+	opts := docker.ListImagesOptions{}
+	if danglingOnly {
+		opts.Filters = map[string][]string{"dangling": {"true"}}
+	}
+	images, err := c.client.ListImages(opts)
+	if err != nil {
+		return 0, err
+	}
+	var reclaimed int64
+	for _, image := range images {
+		refs := taggedRefs(image)
+		if used[image.ID] || danglingOnly && len(refs) > 0 {
+			continue
+		}
+		// Images are removed by ID so that they're removed completely or
+		// not at all. Removing an image with several tags by ID fails
+		// unless it's forced, which is safe since it isn't used.
+		if err := c.client.RemoveImageExtended(image.ID, docker.RemoveImageOptions{
+			Force: len(refs) > 1,
+		}); err != nil {
+			if isConflict(err) || err == docker.ErrNoSuchImage {
+				continue
+			}
+			return reclaimed, fmt.Errorf("error pruning image %s: %v", image.ID, err)
+		}
+		reclaimed += image.Size
+	}
+	return reclaimed, nil
+}
+
+// usedImages returns the IDs of the images used by containers, including
+// stopped ones.
+func (c *Client) usedImages() (map[string]bool, error) {
+	containers, err := c.client.ListContainers(docker.ListContainersOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool)
+	for _, container := range containers {
+		// Listed containers only have the name of their image, which may
+		// have been retagged since, so it's read from their config.
+		inspect, err := c.client.InspectContainer(container.ID)
+		if err != nil {
+			if isContainerNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		used[inspect.Image] = true
+	}
+	return used, nil
+}
+
+// taggedRefs returns image's tags, leaving out the "<none>:<none>" tag that
+// the daemon lists for untagged images.
+func taggedRefs(image docker.APIImages) []string {
+	var refs []string
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			refs = append(refs, tag)
+		}
+	}
+	return refs
+}
+
+// isConflict returns true if err is the daemon refusing a request because it
+// conflicts with the state of another object, e.g. removing an image that a
+// container uses.
+func isConflict(err error) bool {
+	dockerErr, ok := err.(*docker.Error)
+	return ok && dockerErr.Status == http.StatusConflict
+}
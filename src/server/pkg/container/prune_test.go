@@ -0,0 +1,82 @@
+package container
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	until, err := parseUntil("24h", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-24*time.Hour), until)
+	until, err = parseUntil("2017-02-01T00:00:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), until)
+	until, err = parseUntil("1485907200", now)
+	require.NoError(t, err)
+	require.True(t, until.Equal(time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)))
+	_, err = parseUntil("yesterday", now)
+	require.YesError(t, err)
+}
+
+func TestTaggedRefs(t *testing.T) {
+	require.Equal(t, 0, len(taggedRefs(docker.APIImages{RepoTags: []string{"<none>:<none>"}})))
+	require.Equal(t, []string{"busybox:latest", "busybox:1"}, taggedRefs(docker.APIImages{
+		RepoTags: []string{"busybox:latest", "busybox:1"},
+	}))
+}
+
+// fakeImageClient is a DockerClient with images and containers which use
+// them. Calling methods other than those overridden below panics.
+type fakeImageClient struct {
+	DockerClient
+	images     []docker.APIImages
+	containers map[string]string // container ID to image ID
+	removed    []string
+}
+
+func (f *fakeImageClient) ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error) {
+	return f.images, nil
+}
+
+func (f *fakeImageClient) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	var containers []docker.APIContainers
+	for id := range f.containers {
+		containers = append(containers, docker.APIContainers{ID: id})
+	}
+	return containers, nil
+}
+
+func (f *fakeImageClient) InspectContainer(id string) (*docker.Container, error) {
+	return &docker.Container{ID: id, Image: f.containers[id]}, nil
+}
+
+func (f *fakeImageClient) RemoveImageExtended(name string, opts docker.RemoveImageOptions) error {
+	for _, image := range f.images {
+		if image.ID == name && len(taggedRefs(image)) > 1 && !opts.Force {
+			return &docker.Error{Status: http.StatusConflict}
+		}
+	}
+	f.removed = append(f.removed, name)
+	return nil
+}
+
+func TestPruneImagesSkipsUsedImages(t *testing.T) {
+	fake := &fakeImageClient{
+		images: []docker.APIImages{
+			{ID: "used", RepoTags: []string{"a:latest", "b:latest"}, Size: 1},
+			{ID: "unused", RepoTags: []string{"c:latest", "d:latest"}, Size: 2},
+			{ID: "dangling", RepoTags: []string{"<none>:<none>"}, Size: 4},
+		},
+		containers: map[string]string{"stopped": "used"},
+	}
+	reclaimed, err := NewClientFromDockerClient(fake).PruneImages(false)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), reclaimed)
+	require.Equal(t, []string{"unused", "dangling"}, fake.removed)
+}
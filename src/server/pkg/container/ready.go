@@ -0,0 +1,112 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// WaitForPort blocks until containerPort on the container's IP address, see
+// IpAddr, accepts TCP connections. It returns an error if the container exits
+// first, or ctx.Err() if ctx is done first.
+func (c *Client) WaitForPort(ctx context.Context, id string, containerPort int) error {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	dialer := &net.Dialer{Timeout: time.Second}
+	for {
+		container, err := c.client.InspectContainerWithContext(id, ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return containerError(id, err)
+		}
+		if !container.State.Running {
+			return fmt.Errorf("container %s exited with code %d before port %d was ready", id, container.State.ExitCode, containerPort)
+		}
+		if ip := ipAddr(container); ip != "" {
+			conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip, strconv.Itoa(containerPort)))
+			if err == nil {
+				return conn.Close()
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForLogLine blocks until a line of the container's stdout or stderr
+// matches pattern, including lines logged before it was called. It returns an
+// error if the container's logs end first, e.g. because it exited, or
+// ctx.Err() if ctx is done first.
+func (c *Client) WaitForLogLine(ctx context.Context, id string, pattern *regexp.Regexp) error {
+	logsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var matched int32
+	onMatch := func() {
+		atomic.StoreInt32(&matched, 1)
+		cancel()
+	}
+	stdout := &matchWriter{pattern: pattern, onMatch: onMatch}
+	stderr := &matchWriter{pattern: pattern, onMatch: onMatch}
+	err := c.ContainerLogsWithOptions(logsCtx, id, LogsOptions{
+		Output:      stdout,
+		ErrorOutput: stderr,
+		Follow:      true,
+	})
+	// The last line of each stream may not have ended with a newline.
+	stdout.Flush()
+	stderr.Flush()
+	switch {
+	case atomic.LoadInt32(&matched) == 1:
+		return nil
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		return err
+	}
+	return fmt.Errorf("container %s's logs ended without a line matching %q", id, pattern)
+}
+
+// matchWriter calls onMatch when a line written to it matches pattern. Lines
+// can be split across writes.
+type matchWriter struct {
+	pattern *regexp.Regexp
+	onMatch func()
+	buf     []byte
+}
+
+func (m *matchWriter) Write(p []byte) (int, error) {
+	m.buf = append(m.buf, p...)
+	for {
+		i := bytes.IndexByte(m.buf, '\n')
+		if i < 0 {
+			break
+		}
+		m.match(m.buf[:i])
+		m.buf = m.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush matches the buffered partial line, if there is one.
+func (m *matchWriter) Flush() {
+	if len(m.buf) > 0 {
+		m.match(m.buf)
+		m.buf = nil
+	}
+}
+
+func (m *matchWriter) match(line []byte) {
+	if m.pattern.Match(bytes.TrimSuffix(line, []byte("\r"))) {
+		m.onMatch()
+	}
+}
@@ -0,0 +1,24 @@
+package container
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestMatchWriter(t *testing.T) {
+	matches := 0
+	m := &matchWriter{
+		pattern: regexp.MustCompile(`^Server started on port \d+$`),
+		onMatch: func() { matches++ },
+	}
+	m.Write([]byte("starting\nServer sta"))
+	require.Equal(t, 0, matches)
+	m.Write([]byte("rted on port 8080\r\nServer started"))
+	require.Equal(t, 1, matches)
+	m.Write([]byte(" on port 9090"))
+	require.Equal(t, 1, matches)
+	m.Flush()
+	require.Equal(t, 2, matches)
+}
@@ -0,0 +1,70 @@
+package container
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// transientErrors are substrings of errors that are likely to go away if the
+// operation is retried.
+var transientErrors = []string{
+	"TLS handshake timeout",
+	"connection reset",
+	"connection refused",
+	"i/o timeout",
+	"unexpected EOF",
+	"broken pipe",
+	"too many requests",
+	"server misbehaving",
+}
+
+// isTransient returns true if err is likely to go away if the operation which
+// caused it is retried.
+func isTransient(err error) bool {
+	if err == docker.ErrInactivityTimeout || err == docker.ErrConnectionRefused {
+		return true
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	if dockerErr, ok := err.(*docker.Error); ok {
+		switch dockerErr.Status {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			return false
+		}
+		if dockerErr.Status >= http.StatusInternalServerError || dockerErr.Status == http.StatusTooManyRequests {
+			return true
+		}
+	}
+	for _, msg := range transientErrors {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryTransient calls op until it succeeds, returns an error that isn't
+// transient or has been called attempts times. Retries are made with
+// exponential backoff starting at initialBackoff.
+func retryTransient(op func() error, attempts int, initialBackoff time.Duration) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = initialBackoff
+	b.Multiplier = 2
+	b.RandomizationFactor = 0
+	b.MaxElapsedTime = 0
+	attempt := 1
+	return backoff.RetryNotify(op, b, func(err error, _ time.Duration) error {
+		if attempt >= attempts || !isTransient(err) {
+			return err
+		}
+		attempt++
+		return nil
+	})
+}
@@ -0,0 +1,43 @@
+package container
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+func TestPullImageRetry(t *testing.T) {
+	fake := &fakePullClient{errs: []error{
+		errors.New("net/http: TLS handshake timeout"),
+		errors.New("read: connection reset by peer"),
+	}}
+	require.NoError(t, NewClientFromDockerClient(fake).PullImageRetry("busybox:latest", 3, time.Millisecond))
+	require.Equal(t, 3, len(fake.pulled))
+}
+
+func TestPullImageRetryAttempts(t *testing.T) {
+	fake := &fakePullClient{errs: []error{
+		errors.New("net/http: TLS handshake timeout"),
+		errors.New("net/http: TLS handshake timeout"),
+	}}
+	require.YesError(t, NewClientFromDockerClient(fake).PullImageRetry("busybox:latest", 2, time.Millisecond))
+	require.Equal(t, 2, len(fake.pulled))
+}
+
+func TestPullImageRetryPermanent(t *testing.T) {
+	for _, err := range []error{
+		docker.ErrNoSuchImage,
+		&docker.Error{Status: http.StatusNotFound, Message: "manifest unknown"},
+		&docker.Error{Status: http.StatusUnauthorized, Message: "unauthorized"},
+		errors.New("manifest for foo:bar not found"),
+	} {
+		fake := &fakePullClient{errs: []error{err}}
+		require.Equal(t, err, NewClientFromDockerClient(fake).PullImageRetry("busybox:latest", 3, time.Millisecond))
+		require.Equal(t, 1, len(fake.pulled))
+	}
+}
@@ -0,0 +1,71 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// maxSignal is the largest signal number on Linux, including real-time
+// signals.
+const maxSignal = 64
+
+var signals = map[string]docker.Signal{
+	"SIGABRT":   docker.SIGABRT,
+	"SIGALRM":   docker.SIGALRM,
+	"SIGBUS":    docker.SIGBUS,
+	"SIGCHLD":   docker.SIGCHLD,
+	"SIGCLD":    docker.SIGCLD,
+	"SIGCONT":   docker.SIGCONT,
+	"SIGFPE":    docker.SIGFPE,
+	"SIGHUP":    docker.SIGHUP,
+	"SIGILL":    docker.SIGILL,
+	"SIGINT":    docker.SIGINT,
+	"SIGIO":     docker.SIGIO,
+	"SIGIOT":    docker.SIGIOT,
+	"SIGKILL":   docker.SIGKILL,
+	"SIGPIPE":   docker.SIGPIPE,
+	"SIGPOLL":   docker.SIGPOLL,
+	"SIGPROF":   docker.SIGPROF,
+	"SIGPWR":    docker.SIGPWR,
+	"SIGQUIT":   docker.SIGQUIT,
+	"SIGSEGV":   docker.SIGSEGV,
+	"SIGSTKFLT": docker.SIGSTKFLT,
+	"SIGSTOP":   docker.SIGSTOP,
+	"SIGSYS":    docker.SIGSYS,
+	"SIGTERM":   docker.SIGTERM,
+	"SIGTRAP":   docker.SIGTRAP,
+	"SIGTSTP":   docker.SIGTSTP,
+	"SIGTTIN":   docker.SIGTTIN,
+	"SIGTTOU":   docker.SIGTTOU,
+	"SIGUNUSED": docker.SIGUNUSED,
+	"SIGURG":    docker.SIGURG,
+	"SIGUSR1":   docker.SIGUSR1,
+	"SIGUSR2":   docker.SIGUSR2,
+	"SIGVTALRM": docker.SIGVTALRM,
+	"SIGWINCH":  docker.SIGWINCH,
+	"SIGXCPU":   docker.SIGXCPU,
+	"SIGXFSZ":   docker.SIGXFSZ,
+}
+
+// ParseSignal parses a signal given either by name ("SIGTERM" or "TERM") or
+// by number ("15").
+func ParseSignal(signal string) (docker.Signal, error) {
+	if n, err := strconv.Atoi(signal); err == nil {
+		if n < 1 || n > maxSignal {
+			return 0, fmt.Errorf("invalid signal number %d", n)
+		}
+		return docker.Signal(n), nil
+	}
+	name := strings.ToUpper(signal)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	s, ok := signals[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown signal %q", signal)
+	}
+	return s, nil
+}
@@ -0,0 +1,21 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+func TestParseSignal(t *testing.T) {
+	for _, signal := range []string{"SIGTERM", "TERM", "sigterm", "15"} {
+		s, err := ParseSignal(signal)
+		require.NoError(t, err)
+		require.Equal(t, docker.SIGTERM, s)
+	}
+	for _, signal := range []string{"", "SIGFOO", "0", "65", "-9"} {
+		_, err := ParseSignal(signal)
+		require.YesError(t, err)
+	}
+}
@@ -0,0 +1,733 @@
+package container
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// StartOptions specifies how StartContainerWithOptions creates and starts a
+// container.
+type StartOptions struct {
+	// Name names the container, if empty docker generates a name.
+	Name string
+	// Image is the image to run.
+	Image string
+	// Command overrides the image's command.
+	Command []string
+	// Env is set in the container's environment.
+	Env map[string]string
+	// Binds mounts host paths or named volumes into the container, each is
+	// of the form "source:destination[:mode]", e.g. "/host:/container:ro".
+	Binds []string
+	// Mounts mounts named volumes into the container.
+	Mounts []VolumeMount
+	// Ports publishes container ports, e.g. "8080/tcp", on host addresses,
+	// e.g. "0.0.0.0:18080". A host port that's empty or "0" lets docker
+	// pick an ephemeral port.
+	Ports map[string]string
+	// Memory limits the container's memory in bytes.
+	Memory int64
+	// MemorySwap limits the container's memory plus swap in bytes, -1
+	// allows unlimited swap.
+	MemorySwap int64
+	// CPUShares is the container's relative CPU weight.
+	CPUShares int64
+	// CPUQuota limits the container's CPU time, in microseconds per 100ms
+	// period.
+	CPUQuota int64
+	// Labels are attached to the container, see ListOptions.Labels for
+	// finding containers by label.
+	Labels map[string]string
+	// NetworkMode is "bridge", "host", "none", "container:<name|id>" or the
+	// name of a user-defined network to attach the container to.
+	NetworkMode string
+	// AutoRemove removes the container when it exits, like "docker run
+	// --rm". Its exit code and logs can't be retrieved after it's removed, so
+	// WaitContainer and ContainerLogs may fail with AutoRemove; RunAndRemove
+	// captures both and removes the container itself.
+	AutoRemove bool
+	// RestartPolicy is when docker restarts the container after it exits:
+	// "no" (the default), "on-failure", "always" or "unless-stopped".
+	RestartPolicy string
+	// RestartMaxRetries limits how many times the "on-failure" restart
+	// policy restarts the container, 0 means no limit.
+	RestartMaxRetries int
+	// Ulimits sets resource limits in the container, e.g.
+	// NofileUlimit(65536, 65536) raises its file descriptor limit.
+	Ulimits []docker.ULimit
+	// CapAdd and CapDrop add capabilities to and drop them from the
+	// container's default set, e.g. "NET_ADMIN", or "ALL" for every
+	// capability.
+	CapAdd  []string
+	CapDrop []string
+	// Privileged gives the container every capability and access to all of
+	// the host's devices, e.g. for running docker in docker. A privileged
+	// container has nearly the same access to the host as root on the host,
+	// so only images that are fully trusted should be run privileged.
+	Privileged bool
+	// ReadonlyRootfs mounts the container's root filesystem read-only, so it
+	// can only write to volumes and Tmpfs mounts.
+	ReadonlyRootfs bool
+	// Tmpfs mounts in-memory filesystems in the container, keyed by path,
+	// with mount options like "rw,size=64m", which may be empty.
+	Tmpfs map[string]string
+	// DNS sets the IP addresses of the container's DNS servers, DNSSearch its
+	// DNS search domains and DNSOptions its resolver options, e.g.
+	// "ndots:2".
+	DNS        []string
+	DNSSearch  []string
+	DNSOptions []string
+	// ExtraHosts adds entries of the form "hostname:ip" to the container's
+	// /etc/hosts, the ip may be "host-gateway" for the host's address.
+	ExtraHosts []string
+	// Hostname and Domainname set the container's host and domain names, by
+	// default its hostname is its id.
+	Hostname   string
+	Domainname string
+	// User is the user that the container's command runs as, as "uid",
+	// "uid:gid", "user" or "user:group". By default it's the image's user.
+	User string
+	// Entrypoint overrides the image's entrypoint if it's non-nil, an empty
+	// non-nil Entrypoint clears it.
+	Entrypoint []string
+	// Healthcheck overrides the image's healthcheck, which WaitForHealthy
+	// waits on. Its Test is {"CMD", args...}, {"CMD-SHELL", command} or
+	// {"NONE"} to disable the image's healthcheck.
+	Healthcheck *docker.HealthConfig
+	// Devices exposes host devices, e.g. "/dev/fuse", in the container. A
+	// device's PathInContainer defaults to its PathOnHost and its
+	// CgroupPermissions to "rwm". Host paths are checked on this machine,
+	// so they should only be set when the docker daemon is local. GPUs
+	// need the daemon's DeviceRequests, which the vendored docker client
+	// doesn't support yet, so they can't be requested until it's upgraded.
+	Devices []docker.Device
+}
+
+// VolumeMount mounts the named volume Name at Destination in the container.
+type VolumeMount struct {
+	Name        string
+	Destination string
+	ReadOnly    bool
+}
+
+// StartContainerWithOptions starts a container as specified by opts and
+// returns its id.
+func (c *Client) StartContainerWithOptions(opts StartOptions) (string, error) {
+	createOpts, err := opts.createOptions()
+	if err != nil {
+		return "", err
+	}
+	return c.RawStartContainer(createOpts)
+}
+
+// StartContainerWithPorts is like StartContainerWithOptions but also returns
+// the host ports that opts.Ports were bound to, which is how ephemeral ports
+// are discovered.
+func (c *Client) StartContainerWithPorts(opts StartOptions) (string, map[docker.Port][]docker.PortBinding, error) {
+	result, err := c.StartContainerResult(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return result.ID, result.Ports, nil
+}
+
+// StartResult describes a container started by StartContainerResult.
+type StartResult struct {
+	// ID is the container's full ID.
+	ID string
+	// Name is the container's name, which docker generates if opts.Name
+	// was empty.
+	Name string
+	// Ports are the host ports that opts.Ports were bound to.
+	Ports map[docker.Port][]docker.PortBinding
+}
+
+// StartContainerResult is like StartContainerWithOptions but returns the
+// container's ID, name and host port bindings, read from a single inspect
+// once it's started.
+func (c *Client) StartContainerResult(opts StartOptions) (*StartResult, error) {
+	id, err := c.StartContainerWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	container, err := c.client.InspectContainer(id)
+	if err != nil {
+		return nil, containerError(id, err)
+	}
+	result := &StartResult{
+		ID:   id,
+		Name: strings.TrimPrefix(container.Name, "/"),
+	}
+	if container.NetworkSettings != nil {
+		result.Ports = container.NetworkSettings.Ports
+	}
+	return result, nil
+}
+
+// RunAndRemove runs a container as specified by opts, writing its stdout and
+// stderr to out, and returns its exit code once it exits. The container is
+// removed once it exits, or if it fails to start.
+func (c *Client) RunAndRemove(opts StartOptions, out io.Writer) (int, error) {
+	return c.run(opts, out, true)
+}
+
+// run runs a container as specified by opts, writing its stdout and stderr to
+// out, and returns its exit code once it exits. If remove is true the
+// container is removed once it exits, or if it fails to start.
+func (c *Client) run(opts StartOptions, out io.Writer, remove bool) (retExitCode int, retErr error) {
+	// The container is removed here rather than by the daemon, since an
+	// auto-removed container may be gone before it can be waited on.
+	// AutoRemove is still set while validating opts so that restart
+	// policies, which conflict with removal, are rejected.
+	opts.AutoRemove = remove
+	createOpts, err := opts.createOptions()
+	if err != nil {
+		return 0, err
+	}
+	createOpts.HostConfig.AutoRemove = false
+	container, err := c.client.CreateContainer(createOpts)
+	if err != nil {
+		if err == docker.ErrContainerAlreadyExists {
+			return 0, &NameInUseError{Name: opts.Name}
+		}
+		return 0, imageError(opts.Image, err)
+	}
+	if remove {
+		defer func() {
+			if err := c.RemoveContainer(container.ID, true); err != nil && retErr == nil {
+				retErr = err
+			}
+		}()
+	}
+	cw, err := c.attachAndStart(container.ID, createOpts.HostConfig, docker.AttachToContainerOptions{
+		OutputStream: out,
+		ErrorStream:  out,
+		Stdout:       true,
+		Stderr:       true,
+		Stream:       true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := cw.Wait(); err != nil {
+		return 0, err
+	}
+	return c.WaitContainer(container.ID)
+}
+
+// attachAndStart attaches to the created container id as specified by opts,
+// and then starts it with hostConfig so that none of its output is missed.
+func (c *Client) attachAndStart(id string, hostConfig *docker.HostConfig, opts docker.AttachToContainerOptions) (docker.CloseWaiter, error) {
+	success := make(chan struct{})
+	opts.Container = id
+	opts.Success = success
+	cw, err := c.client.AttachToContainerNonBlocking(opts)
+	if err != nil {
+		return nil, err
+	}
+	<-success
+	success <- struct{}{}
+	if err := c.client.StartContainer(id, hostConfig); err != nil {
+		cw.Close()
+		return nil, err
+	}
+	return cw, nil
+}
+
+// RunOptions are the options for RunContainerWithOptions.
+type RunOptions struct {
+	// Name is the container's name, if it's empty docker generates one.
+	Name string
+	// Keep leaves the container in place once it exits, e.g. so that its
+	// files can be copied out of it, rather than removing it. It's then up
+	// to the caller to remove it, which requires Name to find it.
+	Keep bool
+}
+
+// RunContainer runs command in a container from image, pulling image first if
+// it isn't present locally, and returns the container's combined stdout and
+// stderr and its exit code once it exits. The container is removed when it
+// exits.
+func (c *Client) RunContainer(image string, command []string) (string, int, error) {
+	return c.RunContainerWithOptions(image, command, RunOptions{})
+}
+
+// RunContainerWithOptions is like RunContainer but the container is named and
+// kept as specified by opts.
+func (c *Client) RunContainerWithOptions(image string, command []string, opts RunOptions) (string, int, error) {
+	if err := c.EnsureImage(image); err != nil {
+		return "", 0, err
+	}
+	var out bytes.Buffer
+	exitCode, err := c.run(StartOptions{
+		Name:    opts.Name,
+		Image:   image,
+		Command: command,
+	}, &out, !opts.Keep)
+	if err != nil {
+		return "", 0, err
+	}
+	return out.String(), exitCode, nil
+}
+
+// createOptions validates opts and converts them to the options docker
+// expects.
+func (opts StartOptions) createOptions() (docker.CreateContainerOptions, error) {
+	config := DefaultContainerConfig()
+	config.Image = opts.Image
+	config.Cmd = opts.Command
+	config.Entrypoint = opts.Entrypoint
+	config.Env = envList(opts.Env)
+	config.Labels = opts.Labels
+	config.User = opts.User
+	if err := opts.validateResources(); err != nil {
+		return docker.CreateContainerOptions{}, err
+	}
+	hostConfig := &docker.HostConfig{
+		Memory:         opts.Memory,
+		MemorySwap:     opts.MemorySwap,
+		CPUShares:      opts.CPUShares,
+		CPUQuota:       opts.CPUQuota,
+		AutoRemove:     opts.AutoRemove,
+		Privileged:     opts.Privileged,
+		ReadonlyRootfs: opts.ReadonlyRootfs,
+	}
+	restartPolicy, err := opts.restartPolicy()
+	if err != nil {
+		return docker.CreateContainerOptions{}, err
+	}
+	hostConfig.RestartPolicy = restartPolicy
+	for _, bind := range opts.Binds {
+		if err := validateBind(bind); err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		hostConfig.Binds = append(hostConfig.Binds, bind)
+	}
+	for _, mount := range opts.Mounts {
+		bind, err := mount.bind()
+		if err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		hostConfig.Binds = append(hostConfig.Binds, bind)
+	}
+	if opts.NetworkMode != "" {
+		if len(opts.Ports) > 0 && !networkModeSupportsPorts(opts.NetworkMode) {
+			return docker.CreateContainerOptions{}, fmt.Errorf("ports can't be published in network mode %q", opts.NetworkMode)
+		}
+		hostConfig.NetworkMode = opts.NetworkMode
+	}
+	for containerPort, hostAddr := range opts.Ports {
+		port, binding, err := parsePortBinding(containerPort, hostAddr)
+		if err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		if config.ExposedPorts == nil {
+			config.ExposedPorts = make(map[docker.Port]struct{})
+			hostConfig.PortBindings = make(map[docker.Port][]docker.PortBinding)
+		}
+		config.ExposedPorts[port] = struct{}{}
+		hostConfig.PortBindings[port] = append(hostConfig.PortBindings[port], binding)
+	}
+	for _, ulimit := range opts.Ulimits {
+		if err := validateUlimit(ulimit); err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		hostConfig.Ulimits = append(hostConfig.Ulimits, ulimit)
+	}
+	for _, capability := range opts.CapAdd {
+		name, err := parseCapability(capability)
+		if err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		hostConfig.CapAdd = append(hostConfig.CapAdd, name)
+	}
+	for _, capability := range opts.CapDrop {
+		name, err := parseCapability(capability)
+		if err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		hostConfig.CapDrop = append(hostConfig.CapDrop, name)
+	}
+	for dest, options := range opts.Tmpfs {
+		if err := validateTmpfs(dest, options); err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		if hostConfig.Tmpfs == nil {
+			hostConfig.Tmpfs = make(map[string]string)
+		}
+		hostConfig.Tmpfs[dest] = options
+	}
+	for _, server := range opts.DNS {
+		if net.ParseIP(server) == nil {
+			return docker.CreateContainerOptions{}, fmt.Errorf("invalid DNS server %q, must be an IP address", server)
+		}
+	}
+	hostConfig.DNS = opts.DNS
+	hostConfig.DNSSearch = opts.DNSSearch
+	hostConfig.DNSOptions = opts.DNSOptions
+	for _, host := range opts.ExtraHosts {
+		if err := validateExtraHost(host); err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+	}
+	hostConfig.ExtraHosts = opts.ExtraHosts
+	for _, name := range []string{opts.Hostname, opts.Domainname} {
+		if name != "" && !isValidHostname(name) {
+			return docker.CreateContainerOptions{}, fmt.Errorf("invalid hostname %q", name)
+		}
+	}
+	config.Hostname = opts.Hostname
+	config.Domainname = opts.Domainname
+	if opts.Healthcheck != nil {
+		if err := validateHealthcheck(opts.Healthcheck); err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		config.Healthcheck = opts.Healthcheck
+	}
+	for _, device := range opts.Devices {
+		device, err := validateDevice(device)
+		if err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+		hostConfig.Devices = append(hostConfig.Devices, device)
+	}
+	return docker.CreateContainerOptions{
+		Name:       opts.Name,
+		Config:     &config,
+		HostConfig: hostConfig,
+	}, nil
+}
+
+// restartPolicy validates opts' restart policy and converts it to the policy
+// docker expects.
+func (opts StartOptions) restartPolicy() (docker.RestartPolicy, error) {
+	switch opts.RestartPolicy {
+	case "", "no", "always", "unless-stopped", "on-failure":
+	default:
+		return docker.RestartPolicy{}, fmt.Errorf("invalid restart policy %q", opts.RestartPolicy)
+	}
+	if opts.RestartMaxRetries < 0 {
+		return docker.RestartPolicy{}, fmt.Errorf("invalid restart max retries %d", opts.RestartMaxRetries)
+	}
+	if opts.RestartMaxRetries > 0 && opts.RestartPolicy != "on-failure" {
+		return docker.RestartPolicy{}, fmt.Errorf("restart max retries can only be set with the \"on-failure\" restart policy")
+	}
+	if opts.AutoRemove && opts.RestartPolicy != "" && opts.RestartPolicy != "no" {
+		return docker.RestartPolicy{}, fmt.Errorf("auto-removed containers can't be restarted")
+	}
+	return docker.RestartPolicy{
+		Name:              opts.RestartPolicy,
+		MaximumRetryCount: opts.RestartMaxRetries,
+	}, nil
+}
+
+// NofileUlimit returns a ulimit setting the soft and hard limits on the
+// number of open file descriptors.
+func NofileUlimit(soft, hard int64) docker.ULimit {
+	return docker.ULimit{Name: "nofile", Soft: soft, Hard: hard}
+}
+
+// validateUlimit returns an error if ulimit's limits are invalid or its soft
+// limit exceeds its hard limit. A limit of -1 is unlimited.
+func validateUlimit(ulimit docker.ULimit) error {
+	if ulimit.Name == "" {
+		return fmt.Errorf("ulimit has no name")
+	}
+	if ulimit.Soft < -1 || ulimit.Hard < -1 {
+		return fmt.Errorf("invalid %s ulimit %d:%d", ulimit.Name, ulimit.Soft, ulimit.Hard)
+	}
+	if ulimit.Hard != -1 && (ulimit.Soft == -1 || ulimit.Soft > ulimit.Hard) {
+		return fmt.Errorf("soft %s ulimit %d must not be greater than hard ulimit %d", ulimit.Name, ulimit.Soft, ulimit.Hard)
+	}
+	return nil
+}
+
+// validateResources returns an error if the resource limits in opts are
+// negative or inconsistent.
+func (opts StartOptions) validateResources() error {
+	if opts.Memory < 0 {
+		return fmt.Errorf("invalid memory limit %d", opts.Memory)
+	}
+	if opts.MemorySwap < -1 {
+		return fmt.Errorf("invalid memory swap limit %d", opts.MemorySwap)
+	}
+	if opts.MemorySwap > 0 && opts.MemorySwap < opts.Memory {
+		return fmt.Errorf("memory swap limit %d must not be less than memory limit %d", opts.MemorySwap, opts.Memory)
+	}
+	if opts.CPUShares < 0 {
+		return fmt.Errorf("invalid CPU shares %d", opts.CPUShares)
+	}
+	if opts.CPUQuota < 0 {
+		return fmt.Errorf("invalid CPU quota %d", opts.CPUQuota)
+	}
+	return nil
+}
+
+// networkModeSupportsPorts returns false for network modes in which the
+// container doesn't get its own network namespace, and so can't have ports
+// published.
+func networkModeSupportsPorts(mode string) bool {
+	return mode != "host" && mode != "none" && !strings.HasPrefix(mode, "container:")
+}
+
+// bind returns the bind spec which mounts m, named volumes are specified to
+// docker with the same syntax as host paths.
+func (m VolumeMount) bind() (string, error) {
+	if m.Name == "" || strings.ContainsAny(m.Name, "/:") {
+		return "", fmt.Errorf("invalid volume name %q", m.Name)
+	}
+	bind := fmt.Sprintf("%s:%s", m.Name, m.Destination)
+	if m.ReadOnly {
+		bind += ":ro"
+	}
+	if err := validateBind(bind); err != nil {
+		return "", err
+	}
+	return bind, nil
+}
+
+var bindModes = map[string]bool{
+	"ro":       true,
+	"rw":       true,
+	"z":        true,
+	"Z":        true,
+	"nocopy":   true,
+	"shared":   true,
+	"rshared":  true,
+	"slave":    true,
+	"rslave":   true,
+	"private":  true,
+	"rprivate": true,
+}
+
+// validateBind returns an error if bind isn't of the form
+// "source:destination[:mode]".
+func validateBind(bind string) error {
+	parts := strings.Split(bind, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid bind %q, must be of the form source:destination[:mode]", bind)
+	}
+	if parts[0] == "" {
+		return fmt.Errorf("invalid bind %q, source is empty", bind)
+	}
+	if !path.IsAbs(parts[1]) {
+		return fmt.Errorf("invalid bind %q, destination must be an absolute path", bind)
+	}
+	if len(parts) == 3 {
+		for _, mode := range strings.Split(parts[2], ",") {
+			if !bindModes[mode] {
+				return fmt.Errorf("invalid bind %q, unknown mode %q", bind, mode)
+			}
+		}
+	}
+	return nil
+}
+
+var tmpfsFlags = map[string]bool{
+	"rw":            true,
+	"ro":            true,
+	"exec":          true,
+	"noexec":        true,
+	"suid":          true,
+	"nosuid":        true,
+	"dev":           true,
+	"nodev":         true,
+	"sync":          true,
+	"async":         true,
+	"dirsync":       true,
+	"mand":          true,
+	"nomand":        true,
+	"atime":         true,
+	"noatime":       true,
+	"diratime":      true,
+	"nodiratime":    true,
+	"relatime":      true,
+	"norelatime":    true,
+	"strictatime":   true,
+	"nostrictatime": true,
+}
+
+var tmpfsOptionPatterns = map[string]*regexp.Regexp{
+	"size":      regexp.MustCompile(`^[0-9]+[kKmMgG%]?$`),
+	"nr_blocks": regexp.MustCompile(`^[0-9]+[kKmMgG]?$`),
+	"nr_inodes": regexp.MustCompile(`^[0-9]+[kKmMgG]?$`),
+	"mode":      regexp.MustCompile(`^[0-7]{3,4}$`),
+	"uid":       regexp.MustCompile(`^[0-9]+$`),
+	"gid":       regexp.MustCompile(`^[0-9]+$`),
+}
+
+// validateTmpfs returns an error if dest isn't absolute or options aren't
+// valid tmpfs mount options, e.g. "rw,noexec,size=64m,mode=1777".
+func validateTmpfs(dest string, options string) error {
+	if !path.IsAbs(dest) {
+		return fmt.Errorf("invalid tmpfs path %q, must be an absolute path", dest)
+	}
+	if options == "" {
+		return nil
+	}
+	for _, option := range strings.Split(options, ",") {
+		if tmpfsFlags[option] {
+			continue
+		}
+		parts := strings.SplitN(option, "=", 2)
+		pattern, ok := tmpfsOptionPatterns[parts[0]]
+		if !ok {
+			return fmt.Errorf("invalid tmpfs options for %s, unknown option %q", dest, option)
+		}
+		if len(parts) != 2 || !pattern.MatchString(parts[1]) {
+			return fmt.Errorf("invalid tmpfs options for %s, invalid value for %q", dest, parts[0])
+		}
+	}
+	return nil
+}
+
+// validateDevice returns an error if device's host path doesn't exist, its
+// container path isn't absolute or its permissions aren't a combination of
+// "r", "w" and "m". It returns device with its defaults filled in.
+func validateDevice(device docker.Device) (docker.Device, error) {
+	if device.PathOnHost == "" {
+		return docker.Device{}, fmt.Errorf("device has no host path")
+	}
+	if _, err := os.Stat(device.PathOnHost); err != nil {
+		return docker.Device{}, fmt.Errorf("invalid device %s: %v", device.PathOnHost, err)
+	}
+	if device.PathInContainer == "" {
+		device.PathInContainer = device.PathOnHost
+	}
+	if !path.IsAbs(device.PathInContainer) {
+		return docker.Device{}, fmt.Errorf("invalid device %s, container path %q must be absolute", device.PathOnHost, device.PathInContainer)
+	}
+	if device.CgroupPermissions == "" {
+		device.CgroupPermissions = "rwm"
+	}
+	for _, permission := range device.CgroupPermissions {
+		if !strings.ContainsRune("rwm", permission) || strings.Count(device.CgroupPermissions, string(permission)) > 1 {
+			return docker.Device{}, fmt.Errorf("invalid device %s, permissions %q must be a combination of r, w and m", device.PathOnHost, device.CgroupPermissions)
+		}
+	}
+	return device, nil
+}
+
+// validateExtraHost returns an error if host isn't of the form
+// "hostname:ip".
+func validateExtraHost(host string) error {
+	// IPv6 addresses contain colons, so only the first one separates the
+	// hostname.
+	parts := strings.SplitN(host, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("invalid extra host %q, must be of the form hostname:ip", host)
+	}
+	if parts[1] != "host-gateway" && net.ParseIP(parts[1]) == nil {
+		return fmt.Errorf("invalid extra host %q, %q is not an IP address", host, parts[1])
+	}
+	return nil
+}
+
+// hostnameLabel matches a single label of a hostname, as defined by RFC 1123.
+var hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// isValidHostname returns true if name is a legal hostname, i.e. a series of
+// dot-separated labels.
+func isValidHostname(name string) bool {
+	if len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !hostnameLabel.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateHealthcheck returns an error if healthcheck's test isn't of a form
+// docker accepts or its intervals, which default to the image's if zero, are
+// less than a millisecond.
+func validateHealthcheck(healthcheck *docker.HealthConfig) error {
+	if len(healthcheck.Test) == 0 {
+		return fmt.Errorf("healthcheck has no test")
+	}
+	switch healthcheck.Test[0] {
+	case "NONE":
+		if len(healthcheck.Test) != 1 {
+			return fmt.Errorf("a healthcheck test of NONE takes no arguments")
+		}
+	case "CMD":
+		if len(healthcheck.Test) < 2 {
+			return fmt.Errorf("a healthcheck test of CMD needs a command")
+		}
+	case "CMD-SHELL":
+		if len(healthcheck.Test) != 2 {
+			return fmt.Errorf("a healthcheck test of CMD-SHELL takes exactly one command")
+		}
+	default:
+		return fmt.Errorf("healthcheck test must start with CMD, CMD-SHELL or NONE, not %q", healthcheck.Test[0])
+	}
+	if healthcheck.Interval != 0 && healthcheck.Interval < time.Millisecond {
+		return fmt.Errorf("invalid healthcheck interval %v", healthcheck.Interval)
+	}
+	if healthcheck.Timeout != 0 && healthcheck.Timeout < time.Millisecond {
+		return fmt.Errorf("invalid healthcheck timeout %v", healthcheck.Timeout)
+	}
+	if healthcheck.Retries < 0 {
+		return fmt.Errorf("invalid healthcheck retries %d", healthcheck.Retries)
+	}
+	return nil
+}
+
+// parsePortBinding parses a container port of the form "port[/proto]" and a
+// host address of the form "[ip:]port".
+func parsePortBinding(containerPort string, hostAddr string) (docker.Port, docker.PortBinding, error) {
+	port := docker.Port(containerPort)
+	if !strings.Contains(containerPort, "/") {
+		port = docker.Port(containerPort + "/tcp")
+	}
+	if err := validatePort(port.Port(), false); err != nil {
+		return "", docker.PortBinding{}, fmt.Errorf("invalid container port %q: %v", containerPort, err)
+	}
+	switch port.Proto() {
+	case "tcp", "udp", "sctp":
+	default:
+		return "", docker.PortBinding{}, fmt.Errorf("invalid container port %q: unknown protocol %q", containerPort, port.Proto())
+	}
+	var binding docker.PortBinding
+	if i := strings.LastIndex(hostAddr, ":"); i >= 0 {
+		binding.HostIP = strings.Trim(hostAddr[:i], "[]")
+		binding.HostPort = hostAddr[i+1:]
+		if binding.HostIP != "" && net.ParseIP(binding.HostIP) == nil {
+			return "", docker.PortBinding{}, fmt.Errorf("invalid host address %q: bad IP", hostAddr)
+		}
+	} else {
+		binding.HostPort = hostAddr
+	}
+	if binding.HostPort == "0" {
+		binding.HostPort = ""
+	}
+	if err := validatePort(binding.HostPort, true); err != nil {
+		return "", docker.PortBinding{}, fmt.Errorf("invalid host address %q: %v", hostAddr, err)
+	}
+	return port, binding, nil
+}
+
+// validatePort returns an error if port isn't a valid port number, if
+// allowEmpty is true the empty string is also valid.
+func validatePort(port string, allowEmpty bool) error {
+	if port == "" && allowEmpty {
+		return nil
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("bad port %q", port)
+	}
+	return nil
+}
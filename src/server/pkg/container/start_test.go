@@ -0,0 +1,305 @@
+package container
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+func TestValidateBind(t *testing.T) {
+	for _, bind := range []string{
+		"/host:/container",
+		"/host:/container:ro",
+		"volume:/container:rw,z",
+	} {
+		require.NoError(t, validateBind(bind))
+	}
+	for _, bind := range []string{
+		"",
+		"/host",
+		":/container",
+		"/host:container",
+		"/host:/container:rx",
+		"/host:/container:ro:extra",
+	} {
+		require.YesError(t, validateBind(bind))
+	}
+}
+
+func TestCreateOptionsMounts(t *testing.T) {
+	opts, err := StartOptions{
+		Image:  "ubuntu",
+		Binds:  []string{"/in:/pfs/in:ro"},
+		Mounts: []VolumeMount{{Name: "out", Destination: "/pfs/out"}},
+	}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"/in:/pfs/in:ro", "out:/pfs/out"}, opts.HostConfig.Binds)
+
+	_, err = StartOptions{
+		Mounts: []VolumeMount{{Name: "/not/a/name", Destination: "/pfs/out"}},
+	}.createOptions()
+	require.YesError(t, err)
+}
+
+func TestParsePortBinding(t *testing.T) {
+	port, binding, err := parsePortBinding("8080/tcp", "0.0.0.0:18080")
+	require.NoError(t, err)
+	require.Equal(t, docker.Port("8080/tcp"), port)
+	require.Equal(t, docker.PortBinding{HostIP: "0.0.0.0", HostPort: "18080"}, binding)
+
+	port, binding, err = parsePortBinding("53/udp", "0")
+	require.NoError(t, err)
+	require.Equal(t, docker.Port("53/udp"), port)
+	require.Equal(t, docker.PortBinding{}, binding)
+
+	port, binding, err = parsePortBinding("8080", "")
+	require.NoError(t, err)
+	require.Equal(t, docker.Port("8080/tcp"), port)
+	require.Equal(t, docker.PortBinding{}, binding)
+
+	_, binding, err = parsePortBinding("8080", "127.0.0.1:")
+	require.NoError(t, err)
+	require.Equal(t, docker.PortBinding{HostIP: "127.0.0.1"}, binding)
+
+	for _, spec := range [][2]string{
+		{"http", "80"},
+		{"8080/foo", "80"},
+		{"8080", "99999"},
+		{"8080", "notanip:80"},
+	} {
+		_, _, err := parsePortBinding(spec[0], spec[1])
+		require.YesError(t, err)
+	}
+}
+
+func TestCreateOptionsResources(t *testing.T) {
+	opts, err := StartOptions{Memory: 1 << 20, MemorySwap: -1, CPUShares: 512}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<20), opts.HostConfig.Memory)
+	require.Equal(t, int64(-1), opts.HostConfig.MemorySwap)
+	require.Equal(t, int64(512), opts.HostConfig.CPUShares)
+
+	for _, opts := range []StartOptions{
+		{Memory: -1},
+		{Memory: 2 << 20, MemorySwap: 1 << 20},
+		{MemorySwap: -2},
+		{CPUShares: -1},
+		{CPUQuota: -1},
+	} {
+		_, err := opts.createOptions()
+		require.YesError(t, err)
+	}
+}
+
+func TestCreateOptionsNetworkMode(t *testing.T) {
+	opts, err := StartOptions{NetworkMode: "my-network", Ports: map[string]string{"80": ""}}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, "my-network", opts.HostConfig.NetworkMode)
+
+	for _, mode := range []string{"host", "none", "container:other"} {
+		_, err := StartOptions{NetworkMode: mode, Ports: map[string]string{"80": ""}}.createOptions()
+		require.YesError(t, err)
+	}
+}
+
+func TestCreateOptionsRestartPolicy(t *testing.T) {
+	opts, err := StartOptions{RestartPolicy: "on-failure", RestartMaxRetries: 3}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, docker.RestartOnFailure(3), opts.HostConfig.RestartPolicy)
+	opts, err = StartOptions{RestartPolicy: "unless-stopped"}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, docker.RestartUnlessStopped(), opts.HostConfig.RestartPolicy)
+
+	for _, opts := range []StartOptions{
+		{RestartPolicy: "sometimes"},
+		{RestartPolicy: "on-failure", RestartMaxRetries: -1},
+		{RestartPolicy: "always", RestartMaxRetries: 3},
+		{RestartPolicy: "always", AutoRemove: true},
+	} {
+		_, err := opts.createOptions()
+		require.YesError(t, err)
+	}
+}
+
+func TestRunAndRemove(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.EnsureImage("busybox:latest"))
+	var out bytes.Buffer
+	exitCode, err := c.RunAndRemove(StartOptions{
+		Image:   "busybox:latest",
+		Command: []string{"sh", "-c", "echo hello; exit 3"},
+	}, &out)
+	require.NoError(t, err)
+	require.Equal(t, 3, exitCode)
+	require.Equal(t, "hello\n", out.String())
+}
+
+func TestCreateOptionsUlimits(t *testing.T) {
+	opts, err := StartOptions{Ulimits: []docker.ULimit{NofileUlimit(1024, 4096)}}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, []docker.ULimit{{Name: "nofile", Soft: 1024, Hard: 4096}}, opts.HostConfig.Ulimits)
+	_, err = StartOptions{Ulimits: []docker.ULimit{{Name: "memlock", Soft: -1, Hard: -1}}}.createOptions()
+	require.NoError(t, err)
+
+	for _, ulimit := range []docker.ULimit{
+		NofileUlimit(4096, 1024),
+		NofileUlimit(-2, 1024),
+		NofileUlimit(-1, 1024),
+		{Soft: 1, Hard: 1},
+	} {
+		_, err := StartOptions{Ulimits: []docker.ULimit{ulimit}}.createOptions()
+		require.YesError(t, err)
+	}
+}
+
+func TestCreateOptionsPrivileged(t *testing.T) {
+	opts, err := StartOptions{Privileged: true}.createOptions()
+	require.NoError(t, err)
+	require.True(t, opts.HostConfig.Privileged)
+}
+
+func TestValidateTmpfs(t *testing.T) {
+	require.NoError(t, validateTmpfs("/tmp", ""))
+	require.NoError(t, validateTmpfs("/tmp", "rw,noexec,nosuid,size=64m"))
+	require.NoError(t, validateTmpfs("/scratch", "size=50%,mode=1777,uid=1000"))
+	require.YesError(t, validateTmpfs("tmp", ""))
+	require.YesError(t, validateTmpfs("/tmp", "bogus"))
+	require.YesError(t, validateTmpfs("/tmp", "size=lots"))
+	require.YesError(t, validateTmpfs("/tmp", "mode=999"))
+	require.YesError(t, validateTmpfs("/tmp", "size"))
+}
+
+func TestCreateOptionsDNS(t *testing.T) {
+	opts, err := StartOptions{
+		DNS:       []string{"10.0.0.53", "fd00::53"},
+		DNSSearch: []string{"test.local"},
+	}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.53", "fd00::53"}, opts.HostConfig.DNS)
+	require.Equal(t, []string{"test.local"}, opts.HostConfig.DNSSearch)
+	_, err = StartOptions{DNS: []string{"dns.test.local"}}.createOptions()
+	require.YesError(t, err)
+}
+
+func TestValidateExtraHost(t *testing.T) {
+	require.NoError(t, validateExtraHost("db:10.0.0.2"))
+	require.NoError(t, validateExtraHost("db:fd00::2"))
+	require.NoError(t, validateExtraHost("host.docker.internal:host-gateway"))
+	require.YesError(t, validateExtraHost("db"))
+	require.YesError(t, validateExtraHost(":10.0.0.2"))
+	require.YesError(t, validateExtraHost("db:db.local"))
+}
+
+func TestIsValidHostname(t *testing.T) {
+	require.True(t, isValidHostname("worker-1"))
+	require.True(t, isValidHostname("worker-1.cluster.local"))
+	require.False(t, isValidHostname("-worker"))
+	require.False(t, isValidHostname("worker_1"))
+	require.False(t, isValidHostname("worker..local"))
+	require.False(t, isValidHostname(strings.Repeat("a", 64)))
+}
+
+func TestCreateOptionsEntrypoint(t *testing.T) {
+	opts, err := StartOptions{}.createOptions()
+	require.NoError(t, err)
+	require.True(t, opts.Config.Entrypoint == nil)
+	opts, err = StartOptions{Entrypoint: []string{}}.createOptions()
+	require.NoError(t, err)
+	require.True(t, opts.Config.Entrypoint != nil)
+	require.Equal(t, 0, len(opts.Config.Entrypoint))
+}
+
+func TestValidateHealthcheck(t *testing.T) {
+	for _, healthcheck := range []*docker.HealthConfig{
+		{Test: []string{"CMD", "pg_isready"}},
+		{Test: []string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"}, Interval: time.Second, Timeout: time.Second, Retries: 3},
+		{Test: []string{"NONE"}},
+	} {
+		require.NoError(t, validateHealthcheck(healthcheck))
+	}
+	for _, healthcheck := range []*docker.HealthConfig{
+		{},
+		{Test: []string{"pg_isready"}},
+		{Test: []string{"CMD"}},
+		{Test: []string{"CMD-SHELL", "a", "b"}},
+		{Test: []string{"NONE", "a"}},
+		{Test: []string{"CMD", "pg_isready"}, Interval: -time.Second},
+		{Test: []string{"CMD", "pg_isready"}, Timeout: time.Microsecond},
+		{Test: []string{"CMD", "pg_isready"}, Retries: -1},
+	} {
+		require.YesError(t, validateHealthcheck(healthcheck))
+	}
+}
+
+func TestStartContainerResult(t *testing.T) {
+	c := getTestClient(t)
+	require.NoError(t, c.EnsureImage("busybox:latest"))
+	result, err := c.StartContainerResult(StartOptions{
+		Image:   "busybox:latest",
+		Command: []string{"sleep", "60"},
+		Ports:   map[string]string{"8080/tcp": ""},
+	})
+	require.NoError(t, err)
+	defer c.RemoveContainer(result.ID, true)
+	require.NotEqual(t, "", result.Name)
+	require.False(t, strings.HasPrefix(result.Name, "/"))
+	bindings := result.Ports["8080/tcp"]
+	require.Equal(t, 1, len(bindings))
+	require.NotEqual(t, "", bindings[0].HostPort)
+}
+
+func TestCreateOptionsDevices(t *testing.T) {
+	opts, err := StartOptions{Devices: []docker.Device{
+		{PathOnHost: "/dev/null"},
+		{PathOnHost: "/dev/zero", PathInContainer: "/dev/input", CgroupPermissions: "r"},
+	}}.createOptions()
+	require.NoError(t, err)
+	require.Equal(t, []docker.Device{
+		{PathOnHost: "/dev/null", PathInContainer: "/dev/null", CgroupPermissions: "rwm"},
+		{PathOnHost: "/dev/zero", PathInContainer: "/dev/input", CgroupPermissions: "r"},
+	}, opts.HostConfig.Devices)
+
+	for _, device := range []docker.Device{
+		{},
+		{PathOnHost: "/dev/does-not-exist"},
+		{PathOnHost: "/dev/null", PathInContainer: "dev/null"},
+		{PathOnHost: "/dev/null", CgroupPermissions: "rx"},
+		{PathOnHost: "/dev/null", CgroupPermissions: "rr"},
+	} {
+		_, err := StartOptions{Devices: []docker.Device{device}}.createOptions()
+		require.YesError(t, err)
+	}
+}
+
+func TestRunContainer(t *testing.T) {
+	c := getTestClient(t)
+	output, exitCode, err := c.RunContainer("busybox:latest", []string{"sh", "-c", "echo out; echo err >&2; exit 2"})
+	require.NoError(t, err)
+	require.Equal(t, 2, exitCode)
+	require.Equal(t, "out\nerr\n", output)
+}
+
+func TestRunContainerWithOptions(t *testing.T) {
+	c := getTestClient(t)
+	name := "test-run-container-removed"
+	_, _, err := c.RunContainerWithOptions("busybox:latest", []string{"true"}, RunOptions{Name: name})
+	require.NoError(t, err)
+	exists, err := c.ContainerExists(name)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	name = "test-run-container-kept"
+	output, exitCode, err := c.RunContainerWithOptions("busybox:latest", []string{"echo", "kept"}, RunOptions{Name: name, Keep: true})
+	require.NoError(t, err)
+	defer c.RemoveContainer(name, true)
+	require.Equal(t, 0, exitCode)
+	require.Equal(t, "kept\n", output)
+	exists, err = c.ContainerExists(name)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
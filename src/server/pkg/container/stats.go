@@ -0,0 +1,68 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// GetContainerStats returns a single sample of the container's resource
+// usage.
+func (c *Client) GetContainerStats(id string) (*docker.Stats, error) {
+	statsCh := make(chan *docker.Stats)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.client.Stats(docker.StatsOptions{
+			ID:     id,
+			Stats:  statsCh,
+			Stream: false,
+		})
+	}()
+	var result *docker.Stats
+	for stats := range statsCh {
+		result = stats
+	}
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("no stats returned for container %s", id)
+	}
+	return result, nil
+}
+
+// StreamContainerStats returns a channel which receives samples of the
+// container's resource usage until it's removed or ctx is done, at which
+// point the channel is closed.
+func (c *Client) StreamContainerStats(ctx context.Context, id string) (<-chan *docker.Stats, error) {
+	// Inspect first so that a missing container is reported here rather
+	// than by an empty channel.
+	if _, err := c.client.InspectContainerWithContext(id, ctx); err != nil {
+		return nil, containerError(id, err)
+	}
+	statsCh := make(chan *docker.Stats)
+	result := make(chan *docker.Stats)
+	go func() {
+		c.client.Stats(docker.StatsOptions{
+			ID:      id,
+			Stats:   statsCh,
+			Stream:  true,
+			Context: ctx,
+		})
+	}()
+	go func() {
+		defer close(result)
+		for stats := range statsCh {
+			select {
+			case result <- stats:
+			case <-ctx.Done():
+				// Drain statsCh so that Stats can return.
+				for range statsCh {
+				}
+				return
+			}
+		}
+	}()
+	return result, nil
+}
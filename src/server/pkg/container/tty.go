@@ -0,0 +1,50 @@
+package container
+
+import (
+	"io"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// StartInteractive starts a container with a TTY and attaches to it, copying
+// in to the container's input and its output to out. It returns once the
+// container has started, with the container's id, which ResizeTTY takes, and
+// a CloseWaiter whose Wait blocks until the container exits. With a TTY the
+// container's stdout and stderr are a single stream, so both are written to
+// out. As with PipeToStdin, the detach key sequence can't be configured.
+func (c *Client) StartInteractive(image string, command []string, in io.Reader, out io.Writer) (string, docker.CloseWaiter, error) {
+	opts, err := StartOptions{
+		Image:   image,
+		Command: command,
+	}.createOptions()
+	if err != nil {
+		return "", nil, err
+	}
+	opts.Config.Tty = true
+	container, err := c.client.CreateContainer(opts)
+	if err != nil {
+		return "", nil, imageError(image, err)
+	}
+	cw, err := c.attachAndStart(container.ID, opts.HostConfig, docker.AttachToContainerOptions{
+		InputStream:  in,
+		OutputStream: out,
+		ErrorStream:  out,
+		RawTerminal:  true,
+		Stdin:        true,
+		Stdout:       true,
+		Stderr:       true,
+		Stream:       true,
+	})
+	if err != nil {
+		c.RemoveContainer(container.ID, true)
+		return "", nil, err
+	}
+	return container.ID, cw, nil
+}
+
+// ResizeTTY sets the size of the container's TTY, which should be kept in
+// sync with the terminal attached to it. The daemon returns an error for
+// containers which weren't started with a TTY.
+func (c *Client) ResizeTTY(id string, height, width int) error {
+	return c.client.ResizeContainerTTY(id, height, width)
+}
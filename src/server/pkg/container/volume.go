@@ -0,0 +1,32 @@
+package container
+
+import (
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// CreateVolume creates a named volume, which can be mounted into containers
+// with StartOptions.Mounts and outlives them. If driver is empty the volume
+// uses the "local" driver.
+func (c *Client) CreateVolume(name, driver string) (*docker.Volume, error) {
+	return c.client.CreateVolume(docker.CreateVolumeOptions{
+		Name:   name,
+		Driver: driver,
+	})
+}
+
+// RemoveVolume removes the named volume. Removing a volume that doesn't exist
+// is not an error. Removing a volume that's in use by a container, running or
+// not, fails with docker.ErrVolumeInUse; containers are never removed to free
+// it. The vendored docker client can't pass force on to the daemon, so it
+// currently has no effect.
+func (c *Client) RemoveVolume(name string, force bool) error {
+	if err := c.client.RemoveVolume(name); err != nil && err != docker.ErrNoSuchVolume {
+		return err
+	}
+	return nil
+}
+
+// ListVolumes returns all of the daemon's volumes.
+func (c *Client) ListVolumes() ([]docker.Volume, error) {
+	return c.client.ListVolumes(docker.ListVolumesOptions{})
+}
@@ -0,0 +1,41 @@
+package container
+
+import (
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// fakeVolumeClient is a DockerClient with a single volume, which may be used
+// by a container.
+type fakeVolumeClient struct {
+	DockerClient
+	volumeExists    bool
+	containerExists bool
+}
+
+func (f *fakeVolumeClient) RemoveVolume(name string) error {
+	switch {
+	case !f.volumeExists:
+		return docker.ErrNoSuchVolume
+	case f.containerExists:
+		return docker.ErrVolumeInUse
+	}
+	f.volumeExists = false
+	return nil
+}
+
+func TestRemoveVolume(t *testing.T) {
+	fake := &fakeVolumeClient{volumeExists: true, containerExists: true}
+	c := NewClientFromDockerClient(fake)
+	require.Equal(t, docker.ErrVolumeInUse, c.RemoveVolume("volume", false))
+	require.Equal(t, docker.ErrVolumeInUse, c.RemoveVolume("volume", true))
+	require.True(t, fake.volumeExists)
+	require.True(t, fake.containerExists)
+	fake.containerExists = false
+	require.NoError(t, c.RemoveVolume("volume", false))
+	require.False(t, fake.volumeExists)
+	require.NoError(t, c.RemoveVolume("volume", false))
+	require.NoError(t, c.RemoveVolume("volume", true))
+}